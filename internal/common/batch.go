@@ -0,0 +1,145 @@
+package common
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DefaultMaxBatchSize is the largest number of entities sent in one batch
+const DefaultMaxBatchSize = 50
+
+// batchKey groups Set requests that can be sent together
+type batchKey struct {
+	op         Operation
+	entityType string
+}
+
+// batch is a Set request that collects entities until it is flushed
+type batch struct {
+	ctx      context.Context
+	entities []Entity
+	timer    *time.Timer
+	done     chan struct{}
+
+	// Set when done is closed
+	response *Response
+	err      error
+	errs     []error
+}
+
+// batcher coalesces single entity adds and updates of the same entity type
+// that arrive within a short window into one request
+type batcher struct {
+	client  *BaseClient
+	window  time.Duration
+	maxSize int
+
+	mu      sync.Mutex
+	pending map[batchKey]*batch
+}
+
+func newBatcher(client *BaseClient, window time.Duration, maxSize int) *batcher {
+	if window <= 0 {
+		return nil
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultMaxBatchSize
+	}
+	return &batcher{
+		client:  client,
+		window:  window,
+		maxSize: maxSize,
+		pending: make(map[batchKey]*batch),
+	}
+}
+
+// submit adds the entity to the pending batch of its type and waits for the
+// batch to be sent. The error is the status of this entity only. The entity
+// is still sent when ctx is done before the batch is flushed.
+func (b *batcher) submit(ctx context.Context, op Operation, entity Entity) (*Response, error) {
+	key := batchKey{op: op, entityType: entity.EntityType()}
+
+	b.mu.Lock()
+	current := b.pending[key]
+	if current == nil {
+		current = &batch{
+			// The batch is shared, so a single caller giving up must not cancel it
+			ctx:  context.WithoutCancel(ctx),
+			done: make(chan struct{}),
+		}
+		current.timer = time.AfterFunc(b.window, func() { b.flush(key, current) })
+		b.pending[key] = current
+	}
+	index := len(current.entities)
+	current.entities = append(current.entities, entity)
+	full := len(current.entities) >= b.maxSize
+	b.mu.Unlock()
+
+	if full {
+		current.timer.Stop()
+		go b.flush(key, current)
+	}
+
+	select {
+	case <-current.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if current.err != nil {
+		return current.response, current.err
+	}
+	return current.response, current.errs[index]
+}
+
+// flush sends the batch unless it has been sent already
+func (b *batcher) flush(key batchKey, current *batch) {
+	b.mu.Lock()
+	if b.pending[key] != current {
+		b.mu.Unlock()
+		return
+	}
+	delete(b.pending, key)
+	b.mu.Unlock()
+
+	defer close(current.done)
+
+	tflog.SubsystemDebug(current.ctx, logSubsystem(current.ctx), "Sending batched XML API request", map[string]interface{}{
+		"operation":    string(key.op),
+		"entity_count": len(current.entities),
+	})
+
+	current.response, current.err = b.client.sendWithRetry(current.ctx, key.op, current.entities)
+	if current.err == nil {
+		current.err = current.response.Err()
+	}
+	if current.err == nil {
+		current.errs = current.response.entityErrs(len(current.entities))
+	}
+}
+
+// entityErrs returns the status of each of the n entities sent in a Set
+// request. The appliance answers with one element per entity in request
+// order. If the response does not line up with the request, every entity
+// gets the first failure so none is reported as applied by mistake.
+func (r *Response) entityErrs(n int) []error {
+	errs := make([]error, n)
+	if len(r.Entities) == n {
+		for i := range r.Entities {
+			errs[i] = r.EntityErr(i)
+		}
+		return errs
+	}
+
+	for i := range r.Entities {
+		if err := r.EntityErr(i); err != nil {
+			for j := range errs {
+				errs[j] = err
+			}
+			break
+		}
+	}
+	return errs
+}
@@ -0,0 +1,128 @@
+package common
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// batchServer answers Set requests with one status per entity and fails
+// entities named "duplicate"
+type batchServer struct {
+	mu       sync.Mutex
+	requests [][]string
+}
+
+func (s *batchServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Set struct {
+			Entities []struct {
+				XMLName xml.Name
+				Name    string `xml:"Name"`
+			} `xml:",any"`
+		} `xml:"Set"`
+	}
+	if err := xml.Unmarshal([]byte(r.FormValue("reqxml")), &request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var names []string
+	var body strings.Builder
+	body.WriteString(`<Response><Login><status>Authentication Successful</status></Login>`)
+	for _, entity := range request.Set.Entities {
+		names = append(names, entity.Name)
+		if entity.Name == "duplicate" {
+			fmt.Fprintf(&body, `<%s><Status code="502">Operation failed. Entity having same name already exists.</Status></%[1]s>`, entity.XMLName.Local)
+		} else {
+			fmt.Fprintf(&body, `<%s><Status code="200">Configuration applied successfully.</Status></%[1]s>`, entity.XMLName.Local)
+		}
+	}
+	body.WriteString(`</Response>`)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, names)
+	s.mu.Unlock()
+	_, _ = w.Write([]byte(body.String()))
+}
+
+func runBatch(t *testing.T, config Config, names ...string) (*batchServer, map[string]error) {
+	t.Helper()
+	script := &batchServer{}
+	server := httptest.NewServer(script)
+	t.Cleanup(server.Close)
+	config.Endpoint = server.URL
+	client := newTestClient(t, config)
+
+	var mu sync.Mutex
+	errs := make(map[string]error)
+	var wg sync.WaitGroup
+	for _, name := range names {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			_, err := client.Execute(context.Background(), OperationAdd, Ref("IPHost", name))
+			mu.Lock()
+			errs[name] = err
+			mu.Unlock()
+		}(name)
+	}
+	wg.Wait()
+	return script, errs
+}
+
+func TestBatchingMapsStatusToEntities(t *testing.T) {
+	script, errs := runBatch(t, Config{BatchWindow: 100 * time.Millisecond}, "web", "db", "duplicate", "mail")
+
+	if len(script.requests) != 1 || len(script.requests[0]) != 4 {
+		t.Fatalf("expected one request with 4 entities, got %v", script.requests)
+	}
+	for name, err := range errs {
+		var validationErr *ValidationError
+		switch {
+		case name == "duplicate" && !errors.As(err, &validationErr):
+			t.Errorf("%s: expected validation error, got %v", name, err)
+		case name != "duplicate" && err != nil:
+			t.Errorf("%s: unexpected error %v", name, err)
+		}
+	}
+}
+
+func TestBatchingFlushesFullBatches(t *testing.T) {
+	script, errs := runBatch(t, Config{BatchWindow: time.Minute, MaxBatchSize: 2}, "a", "b", "c", "d")
+
+	if len(script.requests) != 2 {
+		t.Fatalf("expected 2 requests, got %v", script.requests)
+	}
+	for name, err := range errs {
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", name, err)
+		}
+	}
+}
+
+func TestAlignResults(t *testing.T) {
+	entities := []Entity{Ref("IPHost", "a"), Ref("IPHost", "b"), Ref("IPHost", "c")}
+	response := &Response{Entities: []EntityResult{{
+		XMLName: xml.Name{Local: "IPHost"},
+		Status:  &Status{Code: "502", Message: "Entity having same name already exists."},
+	}}}
+
+	// Only "b" was missing after the ambiguous failure and got replayed
+	alignResults(response, entities, entities[1:2])
+
+	errs := response.entityErrs(len(entities))
+	if errs[0] != nil || errs[2] != nil {
+		t.Errorf("entities created by the earlier attempt should succeed, got %v", errs)
+	}
+	if errs[1] == nil {
+		t.Error("expected the replayed entity to keep its status")
+	}
+}
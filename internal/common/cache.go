@@ -0,0 +1,142 @@
+package common
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// readCache holds whole entity tables fetched with an unfiltered Get, so
+// that reading many objects of a type costs a single request per run
+type readCache struct {
+	mu          sync.Mutex
+	generations map[string]uint64
+	tables      map[string]*cachedTable
+}
+
+// cachedTable is an entity table that is being fetched or has been fetched
+type cachedTable struct {
+	ready chan struct{}
+
+	// Set when ready is closed
+	response *Response
+	byName   map[string][]EntityResult
+	err      error
+}
+
+func newReadCache(enabled bool) *readCache {
+	if !enabled {
+		return nil
+	}
+	return &readCache{
+		generations: make(map[string]uint64),
+		tables:      make(map[string]*cachedTable),
+	}
+}
+
+// relatedTypes lists the entity types whose table a write to the type can
+// change besides its own: hosts list their groups and groups their hosts
+var relatedTypes = map[string][]string{
+	"IPHost":        {"IPHostGroup"},
+	"IPHostGroup":   {"IPHost"},
+	"FQDNHost":      {"FQDNHostGroup"},
+	"FQDNHostGroup": {"FQDNHost"},
+	"MACHost":       {"MACHostGroup"},
+	"MACHostGroup":  {"MACHost"},
+}
+
+// invalidate drops the cached tables of the written entity types and of the
+// types related to them. The tables of other types are kept.
+func (rc *readCache) invalidate(entityTypes ...string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for _, entityType := range entityTypes {
+		for _, affected := range append([]string{entityType}, relatedTypes[entityType]...) {
+			rc.generations[affected]++
+			delete(rc.tables, affected)
+		}
+	}
+}
+
+// get answers a Get for a single entity reference from the cached table of
+// its type. An empty name returns the whole table in the order the firewall
+// returned it, which for firewall rules is the rule order.
+func (rc *readCache) get(ctx context.Context, c *BaseClient, ref Entity) (*Response, error) {
+	table, err := rc.table(ctx, c, ref.EntityType())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &Response{
+		XMLName:    table.response.XMLName,
+		APIVersion: table.response.APIVersion,
+		Login:      table.response.Login,
+	}
+	if ref.EntityName() == "" {
+		for _, result := range table.response.Entities {
+			if result.XMLName.Local == ref.EntityType() && !result.Empty() {
+				response.Entities = append(response.Entities, result)
+			}
+		}
+	} else {
+		response.Entities = table.byName[ref.EntityName()]
+	}
+	return response, nil
+}
+
+// table returns the table of the entity type, fetching it on first use.
+// Concurrent readers share one fetch, which is not canceled when the reader
+// that started it gives up.
+func (rc *readCache) table(ctx context.Context, c *BaseClient, entityType string) (*cachedTable, error) {
+	rc.mu.Lock()
+	table := rc.tables[entityType]
+	if table == nil {
+		table = &cachedTable{ready: make(chan struct{})}
+		rc.tables[entityType] = table
+		go rc.fetch(context.WithoutCancel(ctx), c, entityType, table, rc.generations[entityType])
+	}
+	rc.mu.Unlock()
+
+	select {
+	case <-table.ready:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return table, table.err
+}
+
+// fetch loads the table with an unfiltered Get. Failed fetches and tables
+// invalidated by a write to their type while they were loading are not
+// kept.
+func (rc *readCache) fetch(ctx context.Context, c *BaseClient, entityType string, table *cachedTable, generation uint64) {
+	defer close(table.ready)
+
+	tflog.SubsystemDebug(ctx, logSubsystem(ctx), "Fetching entity table for read cache", map[string]interface{}{
+		"entity_type": entityType,
+	})
+
+	table.response, table.err = c.sendWithRetry(ctx, OperationGet, []Entity{Ref(entityType, "")})
+	if table.err == nil {
+		table.err = table.response.Err()
+	}
+	if table.err == nil {
+		table.byName = make(map[string][]EntityResult)
+		for _, result := range table.response.Entities {
+			if result.XMLName.Local != entityType || result.Empty() {
+				continue
+			}
+			var ref EntityRef
+			if table.err = result.Decode(&ref); table.err != nil {
+				break
+			}
+			table.byName[ref.Name] = append(table.byName[ref.Name], result)
+		}
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if (table.err != nil || rc.generations[entityType] != generation) && rc.tables[entityType] == table {
+		delete(rc.tables, entityType)
+	}
+}
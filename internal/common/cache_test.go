@@ -0,0 +1,139 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const tableResponse = `<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+	`<IPHost transactionid=""><Name>web</Name><IPFamily>IPv4</IPFamily></IPHost>` +
+	`<IPHost transactionid=""><Name>db</Name><IPFamily>IPv4</IPFamily></IPHost></Response>`
+
+const ruleTableResponse = `<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+	`<FirewallRule transactionid=""><Name>allow-web</Name></FirewallRule></Response>`
+
+func newCacheClient(t *testing.T, script *scriptedServer) *BaseClient {
+	t.Helper()
+	server := httptest.NewServer(script)
+	t.Cleanup(server.Close)
+	return newTestClient(t, Config{Endpoint: server.URL, ReadCache: true})
+}
+
+func readName(t *testing.T, client *BaseClient, name string) string {
+	t.Helper()
+	return readTypeName(t, client, "IPHost", name)
+}
+
+func readTypeName(t *testing.T, client *BaseClient, entityType, name string) string {
+	t.Helper()
+	response, err := client.Execute(context.Background(), OperationGet, Ref(entityType, name))
+	if err != nil {
+		t.Fatalf("get %s: %v", name, err)
+	}
+	refs, err := DecodeEntities[EntityRef](response, entityType)
+	if err != nil {
+		t.Fatalf("decode %s: %v", name, err)
+	}
+	var names []string
+	for _, ref := range refs {
+		names = append(names, ref.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestReadCacheServesReadsFromTable(t *testing.T) {
+	script := &scriptedServer{handlers: []http.HandlerFunc{respond(tableResponse)}}
+	client := newCacheClient(t, script)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := readName(t, client, "web"); got != "web" {
+				t.Errorf("read web = %q", got)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := readName(t, client, "db"); got != "db" {
+		t.Errorf("read db = %q", got)
+	}
+	if got := readName(t, client, "missing"); got != "" {
+		t.Errorf("read missing = %q, want nothing", got)
+	}
+	// The whole table keeps the order of the firewall, e.g. the rule order
+	if got := readName(t, client, ""); got != "web,db" {
+		t.Errorf("read all = %q, want web,db", got)
+	}
+	if len(script.blocks) != 1 {
+		t.Errorf("expected a single table fetch, got %v", script.blocks)
+	}
+}
+
+func TestReadCacheInvalidatedByWrites(t *testing.T) {
+	script := &scriptedServer{handlers: []http.HandlerFunc{
+		respond(tableResponse), respond(okResponse), respond(foundResponse),
+	}}
+	client := newCacheClient(t, script)
+
+	if got := readName(t, client, "db"); got != "db" {
+		t.Errorf("read db = %q", got)
+	}
+	if _, err := client.Execute(context.Background(), OperationRemove, Ref("IPHost", "db")); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if got := readName(t, client, "db"); got != "" {
+		t.Errorf("read db after remove = %q, want nothing", got)
+	}
+	if want := []string{"<Get>", "<Remove>", "<Get>"}; strings.Join(script.blocks, "") != strings.Join(want, "") {
+		t.Errorf("requests = %v, want %v", script.blocks, want)
+	}
+}
+
+func TestReadCacheKeepsUnrelatedTables(t *testing.T) {
+	script := &scriptedServer{handlers: []http.HandlerFunc{
+		respond(tableResponse), respond(ruleTableResponse), respond(okResponse),
+		respond(ruleTableResponse), respond(okResponse), respond(tableResponse),
+	}}
+	client := newCacheClient(t, script)
+
+	if got := readName(t, client, "web"); got != "web" {
+		t.Errorf("read web = %q", got)
+	}
+	if got := readTypeName(t, client, "FirewallRule", "allow-web"); got != "allow-web" {
+		t.Errorf("read allow-web = %q", got)
+	}
+
+	// A write to the rules leaves the IP host table cached
+	if _, err := client.Execute(context.Background(), OperationRemove, Ref("FirewallRule", "allow-db")); err != nil {
+		t.Fatalf("remove rule: %v", err)
+	}
+	if got := readName(t, client, "db"); got != "db" {
+		t.Errorf("read db = %q", got)
+	}
+	if got := readTypeName(t, client, "FirewallRule", "allow-web"); got != "allow-web" {
+		t.Errorf("read allow-web after remove = %q", got)
+	}
+
+	// Host groups list their hosts, so a write to them drops the IP host
+	// table but not the rules
+	if _, err := client.Execute(context.Background(), OperationRemove, Ref("IPHostGroup", "servers")); err != nil {
+		t.Fatalf("remove group: %v", err)
+	}
+	if got := readName(t, client, "web"); got != "web" {
+		t.Errorf("read web after group remove = %q", got)
+	}
+	if got := readTypeName(t, client, "FirewallRule", ""); got != "allow-web" {
+		t.Errorf("read all rules = %q", got)
+	}
+
+	if want := []string{"<Get>", "<Get>", "<Remove>", "<Get>", "<Remove>", "<Get>"}; strings.Join(script.blocks, ",") != strings.Join(want, ",") {
+		t.Errorf("requests = %v, want %v", script.blocks, want)
+	}
+}
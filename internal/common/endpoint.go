@@ -0,0 +1,97 @@
+package common
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// ConfigError reports an invalid connection setting. Setting is the name of
+// the provider attribute.
+type ConfigError struct {
+	Setting string
+	Err     error
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("invalid %s: %v", e.Setting, e.Err)
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// apiURL builds the URL of the XML API controller. A path in the endpoint
+// is kept as prefix, for appliances published behind a reverse proxy path.
+func apiURL(endpoint string, port int, apiPath string) (string, error) {
+	if !strings.Contains(endpoint, "://") {
+		return "", &ConfigError{"endpoint", fmt.Errorf("%q has no scheme, use e.g. https://%s", endpoint, endpoint)}
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", &ConfigError{"endpoint", err}
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return "", &ConfigError{"endpoint", fmt.Errorf("scheme %q is not supported, use https", u.Scheme)}
+	}
+	if u.Hostname() == "" {
+		return "", &ConfigError{"endpoint", fmt.Errorf("%q has no host", endpoint)}
+	}
+	if u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return "", &ConfigError{"endpoint", fmt.Errorf("%q must not contain credentials, a query or a fragment", endpoint)}
+	}
+
+	if port != 0 {
+		if port < 1 || port > 65535 {
+			return "", &ConfigError{"port", fmt.Errorf("%d is not between 1 and 65535", port)}
+		}
+		if u.Port() != "" && u.Port() != strconv.Itoa(port) {
+			return "", &ConfigError{"port", fmt.Errorf("%d conflicts with port %s in the endpoint", port, u.Port())}
+		}
+		u.Host = net.JoinHostPort(u.Hostname(), strconv.Itoa(port))
+	}
+
+	if apiPath == "" {
+		apiPath = APIPath
+	}
+	if !strings.HasPrefix(apiPath, "/") {
+		return "", &ConfigError{"api_path", fmt.Errorf("%q must start with /", apiPath)}
+	}
+	u.Path = strings.TrimRight(u.Path, "/") + apiPath
+	u.RawPath = ""
+
+	return u.String(), nil
+}
+
+// newProxyFunc returns the proxy selection of the HTTP transport. Proxies are
+// taken from HTTPS_PROXY and HTTP_PROXY unless proxyURL overrides them, and
+// NO_PROXY is honored either way.
+func newProxyFunc(proxyURL string) (func(*http.Request) (*url.URL, error), error) {
+	config := httpproxy.FromEnvironment()
+	if proxyURL != "" {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return nil, &ConfigError{"proxy_url", err}
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, &ConfigError{"proxy_url", fmt.Errorf("%q must use the http, https or socks5 scheme", proxyURL)}
+		}
+		if u.Host == "" {
+			return nil, &ConfigError{"proxy_url", fmt.Errorf("%q has no host", proxyURL)}
+		}
+		config.HTTPProxy = proxyURL
+		config.HTTPSProxy = proxyURL
+	}
+
+	proxy := config.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxy(req.URL)
+	}, nil
+}
@@ -0,0 +1,102 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+// APIError is a failure status returned by the XML API
+type APIError struct {
+	// Entity is the entity type the status belongs to, empty for request level errors
+	Entity  string
+	Code    string
+	Message string
+}
+
+func (e *APIError) Error() string {
+	message := e.Message
+	if e.Code != "" {
+		message = e.Code + " - " + message
+	}
+	if e.Entity != "" {
+		return fmt.Sprintf("Sophos API error for %s: %s", e.Entity, message)
+	}
+	return fmt.Sprintf("Sophos API error: %s", message)
+}
+
+// AuthError is returned when the appliance rejects the API credentials
+type AuthError struct{ APIError }
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("authentication failed: %s", e.Message)
+}
+
+// NotFoundError is returned when the referenced entity does not exist
+type NotFoundError struct{ APIError }
+
+// ValidationError is returned when the appliance rejects the request content
+type ValidationError struct{ APIError }
+
+// DependencyError is returned when an entity is still referenced by others
+type DependencyError struct{ APIError }
+
+// PermissionError is returned when the API user or source address is not allowed
+type PermissionError struct{ APIError }
+
+// Status codes returned by the XML API in <Status> and <Error> elements
+const (
+	statusValidationFailed    = "501" // configuration parameters validation failed
+	statusAlreadyExists       = "502" // entity having same name already exists
+	statusEntityNotFound      = "503" // entity not found
+	statusEntityReferenced    = "510" // entity is referenced by other entities
+	statusInvalidRequest      = "529" // input request is not valid
+	statusIPNotAllowed        = "534" // API operations are not allowed from the requester IP
+	statusPermissionDenied    = "535" // API user lacks the required privileges
+	statusOperationNotAllowed = "541" // operation is not allowed for the API user
+)
+
+// isSuccessCode reports whether a status code denotes a successful operation
+func isSuccessCode(code string) bool {
+	return strings.HasPrefix(code, "2")
+}
+
+// newStatusError maps an XML API status to a typed error. Code 500 is a
+// generic failure on all firmware versions, so the message is consulted
+// for any code without a dedicated meaning.
+func newStatusError(entity, code, message string) error {
+	base := APIError{Entity: entity, Code: code, Message: strings.TrimSpace(message)}
+	lower := strings.ToLower(base.Message)
+
+	switch code {
+	case statusValidationFailed, statusAlreadyExists, statusInvalidRequest:
+		return &ValidationError{base}
+	case statusEntityNotFound:
+		return &NotFoundError{base}
+	case statusEntityReferenced:
+		return &DependencyError{base}
+	case statusIPNotAllowed, statusPermissionDenied, statusOperationNotAllowed:
+		return &PermissionError{base}
+	}
+
+	switch {
+	case strings.Contains(lower, "authentication"):
+		return &AuthError{base}
+	case strings.Contains(lower, "not allowed from the requester"),
+		strings.Contains(lower, "privilege"),
+		strings.Contains(lower, "permission"):
+		return &PermissionError{base}
+	case strings.Contains(lower, "referred"),
+		strings.Contains(lower, "referenced"),
+		strings.Contains(lower, "in use"):
+		return &DependencyError{base}
+	case strings.Contains(lower, "not found"),
+		strings.Contains(lower, "does not exist"):
+		return &NotFoundError{base}
+	case strings.Contains(lower, "already exists"),
+		strings.Contains(lower, "invalid"),
+		strings.Contains(lower, "validation"):
+		return &ValidationError{base}
+	}
+
+	return &base
+}
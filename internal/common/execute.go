@@ -0,0 +1,268 @@
+package common
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Operation selects the XML API block an Execute request is sent in
+type Operation string
+
+const (
+	// OperationAdd sends the entities in <Set operation="add">
+	OperationAdd Operation = "add"
+	// OperationUpdate sends the entities in <Set operation="update">
+	OperationUpdate Operation = "update"
+	// OperationGet sends the entities as filters in <Get>
+	OperationGet Operation = "get"
+	// OperationRemove sends the entities in <Remove>
+	OperationRemove Operation = "remove"
+
+	// operationLogin sends only the login block
+	operationLogin Operation = "login"
+)
+
+// Entity is an object that can be carried in a Set, Get or Remove block
+type Entity interface {
+	// EntityType returns the XML tag of the entity, e.g. "IPHost"
+	EntityType() string
+	// EntityName returns the unique name of the entity
+	EntityName() string
+}
+
+// EntityRef refers to an entity by name in Get and Remove requests. An empty
+// name selects every entity of the type.
+type EntityRef struct {
+	XMLName xml.Name
+	Name    string `xml:"Name,omitempty"`
+}
+
+// Ref creates a reference to the named entity of the given type
+func Ref(entityType, name string) *EntityRef {
+	return &EntityRef{XMLName: xml.Name{Local: entityType}, Name: name}
+}
+
+// EntityType returns the XML tag of the referenced entity
+func (r *EntityRef) EntityType() string { return r.XMLName.Local }
+
+// EntityName returns the name of the referenced entity
+func (r *EntityRef) EntityName() string { return r.Name }
+
+// operationBlockXML encodes entities inside a Set, Get or Remove element
+type operationBlockXML struct {
+	Operation string
+	Entities  []Entity
+}
+
+// MarshalXML writes every entity as a child element named after its type
+func (b operationBlockXML) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if b.Operation != "" {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "operation"}, Value: b.Operation})
+	}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, entity := range b.Entities {
+		if err := e.EncodeElement(entity, xml.StartElement{Name: xml.Name{Local: entity.EntityType()}}); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// Response is the envelope returned by the XML API for every request
+type Response struct {
+	XMLName    xml.Name       `xml:"Response"`
+	APIVersion string         `xml:"APIVersion,attr"`
+	Login      *LoginStatus   `xml:"Login"`
+	Status     *Status        `xml:"Status"`
+	Error      *Status        `xml:"Error"`
+	Entities   []EntityResult `xml:",any"`
+}
+
+// LoginStatus holds the outcome of the login block of a request
+type LoginStatus struct {
+	Status string `xml:"status"`
+}
+
+// Status is a status or error element with its numeric code
+type Status struct {
+	Code    string `xml:"code,attr"`
+	Message string `xml:",chardata"`
+}
+
+// EntityResult is a single entity element of a response. For Set and Remove
+// it carries the per-entity status, for Get the entity itself.
+type EntityResult struct {
+	XMLName       xml.Name
+	TransactionID string  `xml:"transactionid,attr"`
+	Status        *Status `xml:"Status"`
+	InnerXML      []byte  `xml:",innerxml"`
+}
+
+// authenticationSuccessful is the login status of an accepted request
+const authenticationSuccessful = "Authentication Successful"
+
+// noRecordsMessage is returned in place of entities when a Get matches nothing
+const noRecordsMessage = "No. of records Zero."
+
+// Empty reports whether the result is the placeholder for a Get without matches
+func (r EntityResult) Empty() bool {
+	return r.Status != nil && r.Status.Code == "" && strings.TrimSpace(r.Status.Message) == noRecordsMessage
+}
+
+// Decode unmarshals the entity element into v
+func (r EntityResult) Decode(v interface{}) error {
+	name := r.XMLName.Local
+	data := make([]byte, 0, len(r.InnerXML)+2*len(name)+5)
+	data = append(data, "<"+name+">"...)
+	data = append(data, r.InnerXML...)
+	data = append(data, "</"+name+">"...)
+	return xml.Unmarshal(data, v)
+}
+
+// Err returns the request level error of the response, if any
+func (r *Response) Err() error {
+	if r.Error != nil && (r.Error.Code != "" || strings.TrimSpace(r.Error.Message) != "") {
+		return newStatusError("", r.Error.Code, r.Error.Message)
+	}
+	if r.Status != nil && r.Status.Code != "" && !isSuccessCode(r.Status.Code) {
+		return newStatusError("", r.Status.Code, r.Status.Message)
+	}
+	if r.Login == nil {
+		return &AuthError{APIError{Message: "no login status in response"}}
+	}
+	if strings.TrimSpace(r.Login.Status) != authenticationSuccessful {
+		return &AuthError{APIError{Message: strings.TrimSpace(r.Login.Status)}}
+	}
+	return nil
+}
+
+// EntityErr returns the error for the entity result at index i, if any
+func (r *Response) EntityErr(i int) error {
+	result := r.Entities[i]
+	if result.Status == nil || result.Status.Code == "" || isSuccessCode(result.Status.Code) {
+		return nil
+	}
+	return newStatusError(result.XMLName.Local, result.Status.Code, result.Status.Message)
+}
+
+// Execute sends the entities in a single request using op and returns the
+// parsed response. Transient failures are retried according to the client
+// retry policy. Request level failures and the first failing entity status
+// are returned as typed errors. With batching enabled, single entity adds
+// and updates are sent together with others of the same type.
+func (c *BaseClient) Execute(ctx context.Context, op Operation, entities ...Entity) (*Response, error) {
+	entityType := ""
+	names := make([]string, 0, len(entities))
+	entityTypes := make([]string, 0, len(entities))
+	for _, entity := range entities {
+		entityType = entity.EntityType()
+		names = append(names, entity.EntityName())
+		entityTypes = append(entityTypes, entityType)
+	}
+	ctx = c.withLogSubsystem(ctx, entityType)
+	subsystem := logSubsystem(ctx)
+	tflog.SubsystemDebug(ctx, subsystem, "Executing XML API operation", map[string]interface{}{
+		"operation": string(op),
+		"entities":  names,
+	})
+
+	if c.cache != nil {
+		if op == OperationGet && len(entities) == 1 {
+			return c.cache.get(ctx, c, entities[0])
+		}
+		if op != OperationGet {
+			// Also when the write fails, it may have been partially applied
+			defer c.cache.invalidate(entityTypes...)
+		}
+	}
+
+	if c.batcher != nil && len(entities) == 1 && (op == OperationAdd || op == OperationUpdate) {
+		return c.batcher.submit(ctx, op, entities[0])
+	}
+
+	response, err := c.sendWithRetry(ctx, op, entities)
+	if err != nil {
+		return nil, err
+	}
+	if err := response.Err(); err != nil {
+		tflog.SubsystemDebug(ctx, subsystem, "XML API request rejected", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return response, err
+	}
+	for i := range response.Entities {
+		if err := response.EntityErr(i); err != nil {
+			tflog.SubsystemDebug(ctx, subsystem, "XML API operation failed", map[string]interface{}{
+				"operation": string(op),
+				"error":     err.Error(),
+			})
+			return response, err
+		}
+	}
+
+	return response, nil
+}
+
+// send marshals the entities into a request using op and sends it once
+func (c *BaseClient) send(ctx context.Context, op Operation, entities []Entity, out *Response) error {
+	xmlData, err := EncodeRequest(op, LoginXML{Username: c.Username, Password: c.Password}, entities...)
+	if err != nil {
+		return err
+	}
+
+	if err := c.SendRequest(ctx, xmlData, out); err != nil {
+		return err
+	}
+	c.recordAPIVersion(out)
+	return nil
+}
+
+// EncodeRequest marshals the entities into an XML API request using op, as
+// it is sent in the reqxml field
+func EncodeRequest(op Operation, login LoginXML, entities ...Entity) ([]byte, error) {
+	block := operationBlockXML{Entities: entities}
+	request := RequestXML{
+		XMLName: xml.Name{Local: "Request"},
+		Login:   login,
+	}
+	switch op {
+	case OperationAdd, OperationUpdate:
+		block.Operation = string(op)
+		request.Set = block
+	case OperationGet:
+		request.Get = block
+	case OperationRemove:
+		request.Remove = block
+	case operationLogin:
+	default:
+		return nil, fmt.Errorf("unsupported XML API operation %q", op)
+	}
+
+	xmlData, err := xml.MarshalIndent(request, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling XML API request: %v", err)
+	}
+	return xmlData, nil
+}
+
+// DecodeEntities decodes every entity of the given type in the response
+func DecodeEntities[T any](response *Response, entityType string) ([]T, error) {
+	var entities []T
+	for _, result := range response.Entities {
+		if result.XMLName.Local != entityType || result.Empty() {
+			continue
+		}
+		var entity T
+		if err := result.Decode(&entity); err != nil {
+			return nil, fmt.Errorf("error unmarshaling %s: %v", entityType, err)
+		}
+		entities = append(entities, entity)
+	}
+	return entities, nil
+}
@@ -0,0 +1,112 @@
+package common
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// xmlText reports whether the values survive XML encoding unchanged. The
+// encoder replaces invalid UTF-8 and characters XML cannot carry.
+func xmlText(values ...string) bool {
+	for _, value := range values {
+		if !utf8.ValidString(value) {
+			return false
+		}
+		for _, r := range value {
+			if r < 0x20 && r != '\t' && r != '\n' && r != '\r' || r == 0xFFFE || r == 0xFFFF {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func FuzzDecodeResponse(f *testing.F) {
+	for _, seed := range []string{
+		okResponse, foundResponse, notFoundResponse, tableResponse,
+		`<Response><Login><status>Authentication Failure</status></Login></Response>`,
+		`<Response><Status code="534">API operations are not allowed from the requester IP address</Status></Response>`,
+		`<Response><Login><status>Authentication Successful</status></Login><IPHost><Status code="502">Operation failed</Status></IPHost></Response>`,
+		`<Response><Login><status>Authentication Successful</status></Login><IPHost><Name>a&amp;b</Name><IPHost>`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var response Response
+		// Decoded the same way as SendRequest does
+		if err := xml.NewDecoder(bytes.NewReader(data)).Decode(&response); err != nil {
+			return
+		}
+		if err := response.Err(); err == nil && strings.TrimSpace(response.Login.Status) != authenticationSuccessful {
+			t.Errorf("accepted response with login status %q", response.Login.Status)
+		}
+		for i, result := range response.Entities {
+			err := response.EntityErr(i)
+			if err != nil && result.Empty() {
+				t.Errorf("placeholder %s reported as error: %v", result.XMLName.Local, err)
+			}
+			var ref EntityRef
+			_ = result.Decode(&ref)
+		}
+	})
+}
+
+func FuzzEncodeRequest(f *testing.F) {
+	f.Add("admin", "s3cret", "web", uint8(0))
+	f.Add(`admin<&>"'`, `p&ss</Password><Username>root`, `</IPHost><IPHost><Name>evil`, uint8(1))
+	f.Add("", "", "]]><![CDATA[", uint8(2))
+
+	operations := []Operation{OperationAdd, OperationUpdate, OperationGet, OperationRemove}
+	f.Fuzz(func(t *testing.T, username, password, name string, op uint8) {
+		operation := operations[int(op)%len(operations)]
+		data, err := EncodeRequest(operation, LoginXML{Username: username, Password: password}, Ref("IPHost", name))
+		if err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+
+		var request struct {
+			Login LoginXML
+			Set   *struct {
+				Entities []EntityResult `xml:",any"`
+			}
+			Get *struct {
+				Entities []EntityResult `xml:",any"`
+			}
+			Remove *struct {
+				Entities []EntityResult `xml:",any"`
+			}
+		}
+		if err := xml.Unmarshal(data, &request); err != nil {
+			t.Fatalf("decode %s: %v", data, err)
+		}
+		var entities []EntityResult
+		switch {
+		case request.Set != nil:
+			entities = request.Set.Entities
+		case request.Get != nil:
+			entities = request.Get.Entities
+		case request.Remove != nil:
+			entities = request.Remove.Entities
+		}
+		if len(entities) != 1 || entities[0].XMLName.Local != "IPHost" {
+			t.Fatalf("request %s does not carry exactly one IPHost", data)
+		}
+		if !xmlText(username, password, name) {
+			return
+		}
+		if request.Login.Username != username || request.Login.Password != password {
+			t.Errorf("login = %q/%q, want %q/%q", request.Login.Username, request.Login.Password, username, password)
+		}
+		var ref EntityRef
+		if err := entities[0].Decode(&ref); err != nil {
+			t.Fatalf("decode entity: %v", err)
+		}
+		if ref.Name != name {
+			t.Errorf("name = %q, want %q", ref.Name, name)
+		}
+	})
+}
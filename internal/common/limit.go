@@ -0,0 +1,64 @@
+package common
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
+)
+
+// DefaultMaxConcurrentRequests keeps parallel Terraform operations from
+// tripping the login lockout of smaller appliances
+const DefaultMaxConcurrentRequests = 4
+
+// newLimiter returns a token bucket allowing requestsPerSecond requests on
+// average, or nil for no limit
+func newLimiter(requestsPerSecond float64) *rate.Limiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	burst := int(math.Max(1, math.Floor(requestsPerSecond)))
+	return rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+}
+
+// newSlots returns a semaphore admitting max requests at a time, or nil for
+// no limit
+func newSlots(max int) chan struct{} {
+	if max <= 0 {
+		return nil
+	}
+	return make(chan struct{}, max)
+}
+
+// acquire waits until the request may be sent under the concurrency and rate
+// limits of the client. The returned function releases the request slot.
+func (c *BaseClient) acquire(ctx context.Context) (func(), error) {
+	start := time.Now()
+	release := func() {}
+
+	if c.slots != nil {
+		select {
+		case c.slots <- struct{}{}:
+			release = func() { <-c.slots }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	slotWait := time.Since(start)
+
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	tflog.SubsystemDebug(ctx, logSubsystem(ctx), "XML API request left queue", map[string]interface{}{
+		"queue_wait_ms":      slotWait.Milliseconds(),
+		"rate_limit_wait_ms": (time.Since(start) - slotWait).Milliseconds(),
+		"in_flight":          len(c.slots),
+	})
+	return release, nil
+}
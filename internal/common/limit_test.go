@@ -0,0 +1,82 @@
+package common
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			old := peak.Load()
+			if current <= old || peak.CompareAndSwap(old, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		_, _ = io.WriteString(w, okResponse)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, Config{Endpoint: server.URL, MaxConcurrentRequests: 2})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Execute(context.Background(), OperationUpdate, Ref("IPHost", "web")); err != nil {
+				t.Errorf("execute: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got != 2 {
+		t.Errorf("peak concurrent requests = %d, want 2", got)
+	}
+}
+
+func TestRequestsPerSecond(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, okResponse)
+	}))
+	defer server.Close()
+
+	// A burst of 10 requests passes at once, the next 5 wait 100ms each
+	client := newTestClient(t, Config{Endpoint: server.URL, RequestsPerSecond: 10})
+
+	start := time.Now()
+	for i := 0; i < 15; i++ {
+		if _, err := client.Execute(context.Background(), OperationUpdate, Ref("IPHost", "web")); err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("15 requests took %v, expected the rate limit to slow them down", elapsed)
+	}
+}
+
+func TestAcquireHonorsContext(t *testing.T) {
+	client := newTestClient(t, Config{Endpoint: "https://firewall.example", MaxConcurrentRequests: 1})
+	release, err := client.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.acquire(ctx); err == nil {
+		t.Fatal("expected acquire to fail while all slots are taken")
+	}
+}
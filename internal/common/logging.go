@@ -0,0 +1,48 @@
+package common
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultSubsystem is used for requests that do not carry any entity
+const defaultSubsystem = "xmlapi"
+
+// loginBlock matches the credentials of a request body. Values are XML
+// escaped, so a literal closing tag can not appear inside the block.
+var loginBlock = regexp.MustCompile(`(?s)<Login>.*?</Login>`)
+
+// subsystemKey is the context key of the tflog subsystem of a request
+type subsystemKey struct{}
+
+// withLogSubsystem sets up the tflog subsystem named after the entity type,
+// e.g. "iphost", with the client credentials masked in every message
+func (c *BaseClient) withLogSubsystem(ctx context.Context, entityType string) context.Context {
+	subsystem := strings.ToLower(entityType)
+	if subsystem == "" {
+		subsystem = defaultSubsystem
+	}
+
+	ctx = tflog.NewSubsystem(ctx, subsystem)
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, subsystem, "password")
+	if c.Password != "" {
+		ctx = tflog.SubsystemMaskLogStrings(ctx, subsystem, c.Password)
+	}
+	return context.WithValue(ctx, subsystemKey{}, subsystem)
+}
+
+// logSubsystem returns the tflog subsystem set up for the request
+func logSubsystem(ctx context.Context) string {
+	if subsystem, ok := ctx.Value(subsystemKey{}).(string); ok {
+		return subsystem
+	}
+	return defaultSubsystem
+}
+
+// redactBody replaces the login block of an XML API request
+func redactBody(body []byte) string {
+	return loginBlock.ReplaceAllString(string(body), "<Login>***</Login>")
+}
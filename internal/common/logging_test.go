@@ -0,0 +1,55 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRedactBody(t *testing.T) {
+	body := []byte("<Request>\n  <Login>\n    <Username>admin</Username>\n    <Password>s&amp;cret</Password>\n  </Login>\n  <Get><IPHost/></Get>\n</Request>")
+
+	got := redactBody(body)
+	if strings.Contains(got, "admin") || strings.Contains(got, "cret") {
+		t.Fatalf("credentials not redacted: %s", got)
+	}
+	if !strings.Contains(got, "<Login>***</Login>") || !strings.Contains(got, "<Get><IPHost/></Get>") {
+		t.Fatalf("unexpected redacted body: %s", got)
+	}
+}
+
+func TestExecuteLogsWithoutCredentials(t *testing.T) {
+	const password = "Sup3r&Secret<pw>"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>`+
+			`<IPHost><Status>No. of records Zero.</Status></IPHost></Response>`)
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	client := newTestClient(t, Config{Endpoint: server.URL, Username: "admin", Password: password})
+	if _, err := client.Execute(ctx, OperationGet, Ref("IPHost", "web")); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+
+	logs := output.String()
+	for _, secret := range []string{password, "Sup3r&amp;Secret&lt;pw&gt;", "<Username>admin"} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("log output contains credential %q:\n%s", secret, logs)
+		}
+	}
+	for _, want := range []string{`"@module":"provider.iphost"`, "XML API request body", "No. of records Zero."} {
+		if !strings.Contains(logs, want) {
+			t.Errorf("log output is missing %q:\n%s", want, logs)
+		}
+	}
+}
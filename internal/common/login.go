@@ -0,0 +1,47 @@
+package common
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// LoginInfo describes the appliance after a successful login
+type LoginInfo struct {
+	// APIVersion is the XML API version of the firmware, e.g. "2000.1"
+	APIVersion string
+}
+
+// loginResult caches the outcome of the first successful Login call.
+// Failures are not cached, so a later call tries again.
+type loginResult struct {
+	mu   sync.Mutex
+	info *LoginInfo
+}
+
+// Login sends a request with only the login block to check that the
+// appliance is reachable and accepts the credentials. A successful result is
+// cached, so only the first successful call talks to the appliance.
+func (c *BaseClient) Login(ctx context.Context) (*LoginInfo, error) {
+	c.login.mu.Lock()
+	defer c.login.mu.Unlock()
+	if c.login.info != nil {
+		return c.login.info, nil
+	}
+
+	ctx = c.withLogSubsystem(ctx, "")
+	response, err := c.sendWithRetry(ctx, operationLogin, nil)
+	if err == nil {
+		err = response.Err()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.login.info = &LoginInfo{APIVersion: response.APIVersion}
+	tflog.SubsystemInfo(ctx, logSubsystem(ctx), "Logged in to Sophos Firewall XML API", map[string]interface{}{
+		"api_version": response.APIVersion,
+	})
+	return c.login.info, nil
+}
@@ -0,0 +1,69 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+const loginFailedResponse = `<Response APIVersion="2000.1"><Login><status>Authentication Failure</status></Login></Response>`
+
+func TestLoginReportsAPIVersionOnce(t *testing.T) {
+	client, script := newRetryClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if reqXML := r.FormValue("reqxml"); reqXML == "" {
+			t.Error("login request without reqxml")
+		}
+		respond(`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login></Response>`)(w, r)
+	})
+
+	for i := 0; i < 2; i++ {
+		info, err := client.Login(context.Background())
+		if err != nil {
+			t.Fatalf("login: %v", err)
+		}
+		if info.APIVersion != "2000.1" {
+			t.Errorf("APIVersion = %q", info.APIVersion)
+		}
+	}
+	if len(script.blocks) != 0 {
+		t.Errorf("login sent operation blocks %v", script.blocks)
+	}
+}
+
+func TestLoginAuthFailure(t *testing.T) {
+	client, _ := newRetryClient(t, respond(loginFailedResponse))
+
+	_, err := client.Login(context.Background())
+	var authErr *AuthError
+	if !errors.As(err, &authErr) {
+		t.Fatalf("expected auth error, got %v", err)
+	}
+}
+
+func TestLoginRetriesAfterFailure(t *testing.T) {
+	client, _ := newRetryClient(t,
+		respond(loginFailedResponse),
+		respond(`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login></Response>`),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.Login(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("login with canceled context = %v", err)
+	}
+	var authErr *AuthError
+	if _, err := client.Login(context.Background()); !errors.As(err, &authErr) {
+		t.Fatalf("expected auth error, got %v", err)
+	}
+	// The success is cached, the server has no more responses
+	for i := 0; i < 2; i++ {
+		info, err := client.Login(context.Background())
+		if err != nil {
+			t.Fatalf("login after failures: %v", err)
+		}
+		if info.APIVersion != "2000.1" {
+			t.Errorf("APIVersion = %q", info.APIVersion)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"sync"
+)
+
+// indentation matches the whitespace between XML tags, which requests are
+// compared without
+var indentation = regexp.MustCompile(`>\s+<`)
+
+// Interaction is one XML API request and its response as stored in a
+// fixture file. Fixture files hold one JSON encoded interaction per line.
+type Interaction struct {
+	// Request is the reqxml field with the login block scrubbed and without
+	// indentation
+	Request    string `json:"request"`
+	StatusCode int    `json:"status_code"`
+	Response   string `json:"response"`
+}
+
+// requestXML returns the reqxml field of an API request with the login
+// block scrubbed and the indentation removed. The request body can be read
+// again afterwards.
+func requestXML(req *http.Request) (string, error) {
+	if req.Body == nil {
+		return "", nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return "", err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	form := req.Clone(req.Context())
+	form.Body = io.NopCloser(bytes.NewReader(body))
+	if err := form.ParseMultipartForm(int64(len(body)) + 1); err != nil {
+		return "", fmt.Errorf("error parsing API request: %w", err)
+	}
+	return indentation.ReplaceAllString(redactBody([]byte(form.FormValue("reqxml"))), "><"), nil
+}
+
+// recordingTransport appends every request and response it forwards to a
+// fixture file, with the credentials scrubbed
+type recordingTransport struct {
+	next http.RoundTripper
+	path string
+	mu   sync.Mutex
+}
+
+func newRecordingTransport(next http.RoundTripper, path string) *recordingTransport {
+	return &recordingTransport{next: next, path: path}
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	request, err := requestXML(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		// Nothing to replay for failures below HTTP
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err := t.append(Interaction{Request: request, StatusCode: resp.StatusCode, Response: string(body)}); err != nil {
+		return nil, fmt.Errorf("error recording API interaction: %w", err)
+	}
+	return resp, nil
+}
+
+// append writes the interaction to the fixture file. The file is opened for
+// every write, so several provider processes of one Terraform run can
+// record into the same file.
+func (t *recordingTransport) append(interaction Interaction) error {
+	var line bytes.Buffer
+	encoder := json.NewEncoder(&line)
+	// Keep the XML readable in the fixture
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(interaction); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	file, err := os.OpenFile(t.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(line.Bytes()); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// ReplayTransport answers API requests from a fixture file instead of an
+// appliance. Each recorded interaction answers one request with the same
+// scrubbed reqxml, in recorded order.
+type ReplayTransport struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// LoadFixture reads a fixture file written in record mode
+func LoadFixture(path string) (*ReplayTransport, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	transport := &ReplayTransport{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 16<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var interaction Interaction
+		if err := json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		if interaction.StatusCode == 0 {
+			interaction.StatusCode = http.StatusOK
+		}
+		transport.interactions = append(transport.interactions, interaction)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	transport.used = make([]bool, len(transport.interactions))
+	return transport, nil
+}
+
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	request, err := requestXML(req)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, interaction := range t.interactions {
+		if t.used[i] || interaction.Request != request {
+			continue
+		}
+		t.used[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.StatusCode, http.StatusText(interaction.StatusCode)),
+			StatusCode:    interaction.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": []string{"text/xml"}},
+			Body:          io.NopCloser(bytes.NewReader([]byte(interaction.Response))),
+			ContentLength: int64(len(interaction.Response)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("no recorded response left for API request: %s", request)
+}
+
+// Unused returns the recorded requests that were not replayed, so a test
+// can check that the client sent everything the fixture expects
+func (t *ReplayTransport) Unused() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var unused []string
+	for i, interaction := range t.interactions {
+		if !t.used[i] {
+			unused = append(unused, interaction.Request)
+		}
+	}
+	return unused
+}
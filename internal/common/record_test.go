@@ -0,0 +1,84 @@
+package common
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(respond(tableResponse))
+	t.Cleanup(server.Close)
+
+	fixture := filepath.Join(t.TempDir(), "session.jsonl")
+	recorder := newTestClient(t, Config{
+		Endpoint:   server.URL,
+		Username:   "admin",
+		Password:   "s3cret",
+		RecordFile: fixture,
+	})
+	recorded, err := recorder.Execute(context.Background(), OperationGet, Ref("IPHost", "web"))
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+
+	data, err := os.ReadFile(fixture)
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	if strings.Contains(string(data), "s3cret") || strings.Contains(string(data), "admin") {
+		t.Errorf("fixture contains credentials: %s", data)
+	}
+	if !strings.Contains(string(data), "<Name>web</Name>") {
+		t.Errorf("fixture does not contain the request: %s", data)
+	}
+
+	// Replay with other credentials, as tests do not know the recorded ones
+	replay, err := LoadFixture(fixture)
+	if err != nil {
+		t.Fatalf("load fixture: %v", err)
+	}
+	client := newTestClient(t, Config{Endpoint: "https://firewall.example", Username: "test", Password: "test"})
+	client.Client.Transport = replay
+
+	replayed, err := client.Execute(context.Background(), OperationGet, Ref("IPHost", "web"))
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if len(replayed.Entities) != len(recorded.Entities) {
+		t.Errorf("replayed %d entities, recorded %d", len(replayed.Entities), len(recorded.Entities))
+	}
+	if unused := replay.Unused(); len(unused) != 0 {
+		t.Errorf("unused interactions: %v", unused)
+	}
+
+	if _, err := client.Execute(context.Background(), OperationGet, Ref("IPHost", "web")); err == nil {
+		t.Error("expected error once the fixture is used up")
+	}
+}
+
+func TestReplayStatusCode(t *testing.T) {
+	fixture := filepath.Join(t.TempDir(), "session.jsonl")
+	request := `<Request><Login>***</Login><Get><IPHost><Name>web</Name></IPHost></Get></Request>`
+	lines := `{"request":"` + request + `","status_code":503,"response":""}` + "\n" +
+		`{"request":"` + request + `","response":"` + strings.ReplaceAll(okResponse, `"`, `\"`) + `"}` + "\n"
+	if err := os.WriteFile(fixture, []byte(lines), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	replay, err := LoadFixture(fixture)
+	if err != nil {
+		t.Fatalf("load fixture: %v", err)
+	}
+
+	client := newTestClient(t, Config{Endpoint: "https://firewall.example", MaxRetries: 1})
+	client.Client.Transport = replay
+	if _, err := client.Execute(context.Background(), OperationGet, Ref("IPHost", "web")); err != nil {
+		t.Fatalf("expected the retry to be answered by the second interaction: %v", err)
+	}
+	if unused := replay.Unused(); len(unused) != 0 {
+		t.Errorf("unused interactions: %v", unused)
+	}
+}
@@ -0,0 +1,213 @@
+package common
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Default retry policy used when the provider does not configure one
+const (
+	DefaultMaxRetries   = 3
+	DefaultRetryWaitMin = time.Second
+	DefaultRetryWaitMax = 30 * time.Second
+)
+
+// TransientError is a failure caused by the appliance being temporarily
+// unavailable, e.g. during a configuration commit or an HA failover
+type TransientError struct {
+	Err error
+	// Ambiguous is set when the request may have been applied by the appliance
+	Ambiguous bool
+}
+
+func (e *TransientError) Error() string { return e.Err.Error() }
+
+func (e *TransientError) Unwrap() error { return e.Err }
+
+// isTransient reports whether err may succeed when the request is retried
+func isTransient(err error) bool {
+	var transientErr *TransientError
+	return errors.As(err, &transientErr)
+}
+
+// isAmbiguous reports whether a failed request may have been applied
+func isAmbiguous(err error) bool {
+	var transientErr *TransientError
+	return errors.As(err, &transientErr) && transientErr.Ambiguous
+}
+
+// newTransportError classifies an error returned by the HTTP client. A
+// request that could not even be connected is safe to replay.
+func newTransportError(err error) error {
+	if isTLSVerificationError(err) {
+		return err
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return &TransientError{Err: err}
+	}
+	return &TransientError{Err: err, Ambiguous: true}
+}
+
+// backoff returns the wait before retry number attempt, starting at zero.
+// The wait doubles with every attempt up to RetryWaitMax, and a random
+// jitter of up to half the wait spreads out concurrent clients.
+func (c *BaseClient) backoff(attempt int) time.Duration {
+	wait := c.RetryWaitMin
+	for i := 0; i < attempt && wait < c.RetryWaitMax; i++ {
+		wait *= 2
+	}
+	if wait > c.RetryWaitMax {
+		wait = c.RetryWaitMax
+	}
+	if wait <= 0 {
+		return 0
+	}
+	return wait/2 + rand.N(wait/2+1)
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// missingEntities returns the entities that do not exist on the appliance.
+// It is used before an add is replayed, as the failed attempt may already
+// have created some of them.
+func (c *BaseClient) missingEntities(ctx context.Context, entities []Entity) ([]Entity, error) {
+	refs := make([]Entity, 0, len(entities))
+	for _, entity := range entities {
+		refs = append(refs, Ref(entity.EntityType(), entity.EntityName()))
+	}
+
+	var response Response
+	if err := c.send(ctx, OperationGet, refs, &response); err != nil {
+		return nil, err
+	}
+	if err := response.Err(); err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]bool)
+	for _, result := range response.Entities {
+		if result.Empty() {
+			continue
+		}
+		var ref EntityRef
+		if err := result.Decode(&ref); err != nil {
+			return nil, err
+		}
+		existing[entityKey(Ref(result.XMLName.Local, ref.Name))] = true
+	}
+
+	var missing []Entity
+	for _, entity := range entities {
+		if !existing[entityKey(entity)] {
+			missing = append(missing, entity)
+		}
+	}
+	return missing, nil
+}
+
+// entityKey identifies an entity by type and name
+func entityKey(entity Entity) string {
+	return entity.EntityType() + "/" + entity.EntityName()
+}
+
+// alignResults makes the results of a replayed add line up with all
+// entities of the original request again. Entities that were not sent
+// because an earlier attempt created them are reported as applied.
+func alignResults(response *Response, entities, sent []Entity) {
+	if len(response.Entities) != len(sent) {
+		return
+	}
+
+	results := make([]EntityResult, 0, len(entities))
+	next := 0
+	for _, entity := range entities {
+		if next < len(sent) && entityKey(sent[next]) == entityKey(entity) {
+			results = append(results, response.Entities[next])
+			next++
+			continue
+		}
+		results = append(results, EntityResult{
+			XMLName: xml.Name{Local: entity.EntityType()},
+			Status:  &Status{Code: "200", Message: "Created by an earlier attempt of the request"},
+		})
+	}
+	response.Entities = results
+}
+
+// sendWithRetry sends the entities and retries transient failures with
+// exponential backoff. Adds are only replayed for the entities that the
+// appliance does not know yet after an ambiguous failure.
+func (c *BaseClient) sendWithRetry(ctx context.Context, op Operation, entities []Entity) (*Response, error) {
+	subsystem := logSubsystem(ctx)
+	pending := entities
+	checkExisting := false
+
+	for attempt := 0; ; attempt++ {
+		if checkExisting {
+			missing, err := c.missingEntities(ctx, pending)
+			if err != nil {
+				if !isTransient(err) || attempt >= c.MaxRetries {
+					return nil, err
+				}
+				if c.waitForRetry(ctx, attempt, err) != nil {
+					return nil, err
+				}
+				continue
+			}
+			checkExisting = false
+			if len(missing) == 0 {
+				tflog.SubsystemDebug(ctx, subsystem, "Entities were created by the failed attempt, not replaying add")
+				response := &Response{Login: &LoginStatus{Status: authenticationSuccessful}}
+				alignResults(response, entities, nil)
+				return response, nil
+			}
+			pending = missing
+		}
+
+		var response Response
+		err := c.send(ctx, op, pending, &response)
+		if err == nil {
+			if len(pending) != len(entities) {
+				alignResults(&response, entities, pending)
+			}
+			return &response, nil
+		}
+		if !isTransient(err) || attempt >= c.MaxRetries {
+			return nil, err
+		}
+		checkExisting = op == OperationAdd && isAmbiguous(err)
+		if c.waitForRetry(ctx, attempt, err) != nil {
+			return nil, err
+		}
+	}
+}
+
+// waitForRetry logs the failed attempt and waits for its backoff. It fails
+// when ctx is done before the next attempt is due.
+func (c *BaseClient) waitForRetry(ctx context.Context, attempt int, err error) error {
+	wait := c.backoff(attempt)
+	tflog.SubsystemWarn(ctx, logSubsystem(ctx), "Retrying XML API request after transient failure", map[string]interface{}{
+		"attempt":     attempt + 1,
+		"max_retries": c.MaxRetries,
+		"wait_ms":     wait.Milliseconds(),
+		"error":       err.Error(),
+	})
+	return sleep(ctx, wait)
+}
@@ -0,0 +1,153 @@
+package common
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	okResponse = `<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+		`<IPHost transactionid=""><Status code="200">Configuration applied successfully.</Status></IPHost></Response>`
+	foundResponse = `<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+		`<IPHost transactionid=""><Name>web</Name><IPFamily>IPv4</IPFamily></IPHost></Response>`
+	notFoundResponse = `<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+		`<IPHost><Status>No. of records Zero.</Status></IPHost></Response>`
+)
+
+// scriptedServer answers requests with the given handlers in order and
+// records which XML API block every request carried
+type scriptedServer struct {
+	mu       sync.Mutex
+	handlers []http.HandlerFunc
+	blocks   []string
+}
+
+func (s *scriptedServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reqXML := r.FormValue("reqxml")
+	for _, block := range []string{`<Set operation="add">`, `<Set operation="update">`, "<Get>", "<Remove>"} {
+		if strings.Contains(reqXML, block) {
+			s.blocks = append(s.blocks, block)
+		}
+	}
+	if len(s.handlers) == 0 {
+		http.Error(w, "unexpected request", http.StatusTeapot)
+		return
+	}
+	handler := s.handlers[0]
+	s.handlers = s.handlers[1:]
+	handler(w, r)
+}
+
+func respond(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) { _, _ = io.WriteString(w, body) }
+}
+
+func respondStatus(code int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(code) }
+}
+
+func newRetryClient(t *testing.T, handlers ...http.HandlerFunc) (*BaseClient, *scriptedServer) {
+	t.Helper()
+	script := &scriptedServer{handlers: handlers}
+	server := httptest.NewServer(script)
+	t.Cleanup(server.Close)
+	return newTestClient(t, Config{
+		Endpoint:     server.URL,
+		MaxRetries:   2,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: 5 * time.Millisecond,
+	}), script
+}
+
+func TestExecuteRetriesEmptyResponse(t *testing.T) {
+	client, script := newRetryClient(t, respond(""), respondStatus(http.StatusServiceUnavailable), respond(okResponse))
+
+	if _, err := client.Execute(context.Background(), OperationUpdate, Ref("IPHost", "web")); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if len(script.blocks) != 3 {
+		t.Errorf("expected 3 attempts, got %v", script.blocks)
+	}
+}
+
+func TestExecuteGivesUpAfterMaxRetries(t *testing.T) {
+	client, script := newRetryClient(t, respond(""), respond(""), respond(""), respond(okResponse))
+
+	_, err := client.Execute(context.Background(), OperationGet, Ref("IPHost", "web"))
+	if err == nil || !strings.Contains(err.Error(), "empty response") {
+		t.Fatalf("expected empty response error, got %v", err)
+	}
+	if len(script.blocks) != 3 {
+		t.Errorf("expected 3 attempts, got %v", script.blocks)
+	}
+}
+
+func TestExecuteDoesNotRetryPermanentFailures(t *testing.T) {
+	client, script := newRetryClient(t, respondStatus(http.StatusForbidden), respond(okResponse))
+
+	if _, err := client.Execute(context.Background(), OperationGet, Ref("IPHost", "web")); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(script.blocks) != 1 {
+		t.Errorf("expected a single attempt, got %v", script.blocks)
+	}
+}
+
+func TestExecuteAddIsNotReplayedWhenEntityExists(t *testing.T) {
+	client, script := newRetryClient(t, respondStatus(http.StatusBadGateway), respond(foundResponse))
+
+	if _, err := client.Execute(context.Background(), OperationAdd, Ref("IPHost", "web")); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	want := []string{`<Set operation="add">`, "<Get>"}
+	if strings.Join(script.blocks, ",") != strings.Join(want, ",") {
+		t.Errorf("requests = %v, want %v", script.blocks, want)
+	}
+}
+
+func TestExecuteAddIsReplayedWhenEntityIsMissing(t *testing.T) {
+	client, script := newRetryClient(t, respond(""), respond(notFoundResponse), respond(okResponse))
+
+	if _, err := client.Execute(context.Background(), OperationAdd, Ref("IPHost", "web")); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	want := []string{`<Set operation="add">`, "<Get>", `<Set operation="add">`}
+	if strings.Join(script.blocks, ",") != strings.Join(want, ",") {
+		t.Errorf("requests = %v, want %v", script.blocks, want)
+	}
+}
+
+func TestExecuteAddIsReplayedAfterUnambiguousFailure(t *testing.T) {
+	client, script := newRetryClient(t, respondStatus(http.StatusTooManyRequests), respond(okResponse))
+
+	if _, err := client.Execute(context.Background(), OperationAdd, Ref("IPHost", "web")); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	want := []string{`<Set operation="add">`, `<Set operation="add">`}
+	if strings.Join(script.blocks, ",") != strings.Join(want, ",") {
+		t.Errorf("requests = %v, want %v", script.blocks, want)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	client := &BaseClient{RetryWaitMin: 100 * time.Millisecond, RetryWaitMax: time.Second}
+
+	for attempt, ceiling := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		ceiling *= time.Millisecond
+		for i := 0; i < 20; i++ {
+			wait := client.backoff(attempt)
+			if wait < ceiling/2 || wait > ceiling {
+				t.Fatalf("backoff(%d) = %v, want between %v and %v", attempt, wait, ceiling/2, ceiling)
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package common
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNoCACerts is returned by NewBaseClient when the CA bundle holds no
+// certificate
+var ErrNoCACerts = errors.New("CA certificate bundle contains no PEM encoded certificate")
+
+// FingerprintMismatchError is returned when the appliance presents a
+// certificate other than the pinned one
+type FingerprintMismatchError struct {
+	Expected []byte
+	Actual   []byte
+}
+
+func (e *FingerprintMismatchError) Error() string {
+	return fmt.Sprintf("certificate fingerprint mismatch: the appliance presented SHA-256 %s, expected %s",
+		FormatCertFingerprint(e.Actual), FormatCertFingerprint(e.Expected))
+}
+
+// ParseCertFingerprint parses a SHA-256 certificate fingerprint given as hex,
+// optionally separated by colons as printed by openssl
+func ParseCertFingerprint(fingerprint string) ([]byte, error) {
+	digest, err := hex.DecodeString(strings.ReplaceAll(strings.TrimSpace(fingerprint), ":", ""))
+	if err != nil {
+		return nil, fmt.Errorf("certificate fingerprint is not hex encoded: %w", err)
+	}
+	if len(digest) != sha256.Size {
+		return nil, fmt.Errorf("certificate fingerprint has %d bytes, a SHA-256 fingerprint has %d", len(digest), sha256.Size)
+	}
+	return digest, nil
+}
+
+// FormatCertFingerprint formats a fingerprint the way openssl prints it
+func FormatCertFingerprint(digest []byte) string {
+	parts := make([]string, len(digest))
+	for i, b := range digest {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, ":")
+}
+
+// newTLSConfig builds the TLS settings of the HTTP transport
+func newTLSConfig(config Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.Insecure,
+		ServerName:         config.TLSServerName,
+		MinVersion:         config.MinTLSVersion,
+	}
+	if tlsConfig.MinVersion == 0 {
+		tlsConfig.MinVersion = tls.VersionTLS12
+	}
+
+	if len(config.CACertPEM) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(config.CACertPEM) {
+			return nil, ErrNoCACerts
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if len(config.CertFingerprint) > 0 {
+		if len(config.CertFingerprint) != sha256.Size {
+			return nil, fmt.Errorf("certificate fingerprint has %d bytes, a SHA-256 fingerprint has %d", len(config.CertFingerprint), sha256.Size)
+		}
+		expected := config.CertFingerprint
+		// Runs after chain verification, and also when insecure skips it so a
+		// self-signed appliance certificate can be trusted by its fingerprint
+		tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			if len(state.PeerCertificates) == 0 {
+				return errors.New("the appliance presented no certificate")
+			}
+			actual := sha256.Sum256(state.PeerCertificates[0].Raw)
+			if !bytes.Equal(actual[:], expected) {
+				return &FingerprintMismatchError{Expected: expected, Actual: actual[:]}
+			}
+			return nil
+		}
+	}
+
+	return tlsConfig, nil
+}
+
+// isTLSVerificationError reports whether the request failed because the
+// appliance certificate was not trusted. Retrying cannot fix that.
+func isTLSVerificationError(err error) bool {
+	var (
+		verificationErr *tls.CertificateVerificationError
+		unknownAuthErr  x509.UnknownAuthorityError
+		hostnameErr     x509.HostnameError
+		invalidErr      x509.CertificateInvalidError
+		mismatchErr     *FingerprintMismatchError
+	)
+	return errors.As(err, &verificationErr) || errors.As(err, &unknownAuthErr) ||
+		errors.As(err, &hostnameErr) || errors.As(err, &invalidErr) || errors.As(err, &mismatchErr)
+}
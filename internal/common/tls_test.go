@@ -0,0 +1,76 @@
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTLSServer(t *testing.T) (*httptest.Server, []byte) {
+	t.Helper()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, okResponse)
+	}))
+	t.Cleanup(server.Close)
+	return server, server.Certificate().Raw
+}
+
+func TestCACertPEM(t *testing.T) {
+	server, der := newTLSServer(t)
+
+	client := newTestClient(t, Config{
+		Endpoint:  server.URL,
+		CACertPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	})
+	if _, err := client.Execute(context.Background(), OperationUpdate, Ref("IPHost", "web")); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+
+	if _, err := NewBaseClient(Config{Endpoint: server.URL, CACertPEM: []byte("not a certificate")}); !errors.Is(err, ErrNoCACerts) {
+		t.Errorf("expected ErrNoCACerts, got %v", err)
+	}
+}
+
+func TestCertFingerprintPin(t *testing.T) {
+	server, der := newTLSServer(t)
+	digest := sha256.Sum256(der)
+
+	client := newTestClient(t, Config{Endpoint: server.URL, Insecure: true, CertFingerprint: digest[:]})
+	if _, err := client.Execute(context.Background(), OperationUpdate, Ref("IPHost", "web")); err != nil {
+		t.Fatalf("execute with matching pin: %v", err)
+	}
+
+	other := sha256.Sum256([]byte("another certificate"))
+	client = newTestClient(t, Config{Endpoint: server.URL, Insecure: true, CertFingerprint: other[:], MaxRetries: 3})
+	_, err := client.Execute(context.Background(), OperationUpdate, Ref("IPHost", "web"))
+	var mismatchErr *FingerprintMismatchError
+	if !errors.As(err, &mismatchErr) {
+		t.Fatalf("expected fingerprint mismatch, got %v", err)
+	}
+	if isTransient(err) {
+		t.Error("a fingerprint mismatch must not be retried")
+	}
+}
+
+func TestParseCertFingerprint(t *testing.T) {
+	digest := sha256.Sum256([]byte("certificate"))
+	formatted := FormatCertFingerprint(digest[:])
+
+	for _, input := range []string{formatted, strings.ToLower(strings.ReplaceAll(formatted, ":", ""))} {
+		parsed, err := ParseCertFingerprint(input)
+		if err != nil || string(parsed) != string(digest[:]) {
+			t.Errorf("ParseCertFingerprint(%q) = %x, %v", input, parsed, err)
+		}
+	}
+	for _, input := range []string{"zz", "AB:CD"} {
+		if _, err := ParseCertFingerprint(input); err == nil {
+			t.Errorf("ParseCertFingerprint(%q) should fail", input)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// APIPath is the path of the XML API controller on the appliance
+const APIPath = "/webconsole/APIController"
+
+// SendRequest posts an XML API request as the multipart "reqxml" field and
+// decodes the XML response body into out while it is being read. Bodies are
+// logged at TRACE only, with the login block removed.
+func (c *BaseClient) SendRequest(ctx context.Context, payload []byte, out interface{}) error {
+	if _, ok := ctx.Value(subsystemKey{}).(string); !ok {
+		ctx = c.withLogSubsystem(ctx, "")
+	}
+	subsystem := logSubsystem(ctx)
+
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("error waiting to send request to Sophos API: %w", err)
+	}
+	defer release()
+
+	// Failures caused by the caller giving up must not be retried
+	parent := ctx
+	if c.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.RequestTimeout)
+		defer cancel()
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	field, err := form.CreateFormField("reqxml")
+	if err != nil {
+		return fmt.Errorf("error creating multipart request: %v", err)
+	}
+	if _, err := field.Write(payload); err != nil {
+		return fmt.Errorf("error writing multipart request: %v", err)
+	}
+	if err := form.Close(); err != nil {
+		return fmt.Errorf("error closing multipart request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, &body)
+	if err != nil {
+		return fmt.Errorf("error creating HTTP request: %v", err)
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	tflog.SubsystemDebug(ctx, subsystem, "Sending XML API request", map[string]interface{}{
+		"url":          c.URL,
+		"request_size": len(payload),
+	})
+	tflog.SubsystemTrace(ctx, subsystem, "XML API request body", map[string]interface{}{
+		"body": redactBody(payload),
+	})
+
+	start := time.Now()
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		tflog.SubsystemDebug(ctx, subsystem, "XML API request failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		err = fmt.Errorf("error sending request to Sophos API: %w", err)
+		if parent.Err() != nil {
+			return err
+		}
+		return newTransportError(err)
+	}
+	defer resp.Body.Close()
+
+	tflog.SubsystemDebug(ctx, subsystem, "Received XML API response", map[string]interface{}{
+		"status_code": resp.StatusCode,
+		"duration_ms": time.Since(start).Milliseconds(),
+	})
+
+	if resp.StatusCode != http.StatusOK {
+		// Drain the body so the connection can be reused
+		_, _ = io.Copy(io.Discard, resp.Body)
+		err := fmt.Errorf("unexpected HTTP status from Sophos API: %s", resp.Status)
+		switch {
+		case resp.StatusCode == http.StatusTooManyRequests:
+			return &TransientError{Err: err}
+		case resp.StatusCode >= http.StatusInternalServerError:
+			return &TransientError{Err: err, Ambiguous: true}
+		}
+		return err
+	}
+
+	// Keep a copy of the body for the TRACE log while it is decoded
+	var received bytes.Buffer
+	err = xml.NewDecoder(io.TeeReader(resp.Body, &received)).Decode(out)
+	tflog.SubsystemTrace(ctx, subsystem, "XML API response body", map[string]interface{}{
+		"body": received.String(),
+	})
+	if err != nil {
+		if err == io.EOF {
+			err = fmt.Errorf("received empty response from Sophos API")
+		} else {
+			err = fmt.Errorf("error unmarshaling XML API response: %w", err)
+		}
+		if parent.Err() != nil {
+			return err
+		}
+		// The appliance returns empty or truncated bodies while it is busy
+		return &TransientError{Err: err, Ambiguous: true}
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSendRequestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := newTestClient(t, Config{Endpoint: server.URL, RequestTimeout: 50 * time.Millisecond})
+
+	var response Response
+	err := client.SendRequest(context.Background(), []byte("<Request/>"), &response)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestSendRequestCanceled(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := newTestClient(t, Config{Endpoint: server.URL})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	var response Response
+	err := client.SendRequest(ctx, []byte("<Request/>"), &response)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected canceled request, got %v", err)
+	}
+}
+
+func newTestClient(t *testing.T, config Config) *BaseClient {
+	t.Helper()
+	client, err := NewBaseClient(config)
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	return client
+}
@@ -1,32 +1,120 @@
 package common
 
 import (
-	"crypto/tls"
 	"net/http"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
 )
 
-// BaseClient provides common client functionality for all service clients
-type BaseClient struct {
+// Config holds the connection settings of a BaseClient
+type Config struct {
 	Endpoint string
 	Username string
 	Password string
-	Client   *http.Client
+	Insecure bool
+	// Port overrides the port of the endpoint
+	Port int
+	// APIPath is appended to the endpoint, defaults to APIPath
+	APIPath string
+	// ProxyURL overrides the HTTPS_PROXY and HTTP_PROXY environment variables
+	ProxyURL string
+	// CACertPEM replaces the system roots for verifying the appliance certificate
+	CACertPEM []byte
+	// TLSServerName is the name verified in the appliance certificate when it
+	// differs from the endpoint host
+	TLSServerName string
+	// CertFingerprint pins the SHA-256 digest of the appliance certificate
+	CertFingerprint []byte
+	// MinTLSVersion defaults to TLS 1.2
+	MinTLSVersion uint16
+	// RequestTimeout limits each HTTP request to the API, zero means no limit
+	RequestTimeout time.Duration
+	// MaxRetries is the number of times a transient failure is retried
+	MaxRetries int
+	// RetryWaitMin and RetryWaitMax bound the exponential backoff between retries
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+	// MaxConcurrentRequests limits the requests in flight, zero means no limit
+	MaxConcurrentRequests int
+	// RequestsPerSecond limits the request rate, zero means no limit
+	RequestsPerSecond float64
+	// BatchWindow enables batching of single entity adds and updates that
+	// arrive within the window, zero disables batching
+	BatchWindow time.Duration
+	// MaxBatchSize limits the entities sent in one batch
+	MaxBatchSize int
+	// ReadCache serves reads from whole entity tables fetched once per run
+	ReadCache bool
+	// RecordFile appends every request and response to this fixture file,
+	// with the credentials scrubbed, for replay in regression tests
+	RecordFile string
+}
+
+// BaseClient provides common client functionality for all service clients
+type BaseClient struct {
+	Endpoint       string
+	Username       string
+	Password       string
+	URL            string
+	RequestTimeout time.Duration
+	MaxRetries     int
+	RetryWaitMin   time.Duration
+	RetryWaitMax   time.Duration
+	Client         *http.Client
+
+	// slots and limiter are shared by all service clients using this client
+	slots   chan struct{}
+	limiter *rate.Limiter
+	batcher *batcher
+	cache   *readCache
+	login   loginResult
+
+	// apiVersion is the version of the latest response
+	apiVersion atomic.Value
 }
 
 // NewBaseClient creates a new base client
-func NewBaseClient(endpoint, username, password string, insecure bool) *BaseClient {
-	return &BaseClient{
-		Endpoint: endpoint,
-		Username: username,
-		Password: password,
-		Client: &http.Client{
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{
-					InsecureSkipVerify: insecure,
-				},
-			},
-		},
+func NewBaseClient(config Config) (*BaseClient, error) {
+	url, err := apiURL(config.Endpoint, config.Port, config.APIPath)
+	if err != nil {
+		return nil, err
 	}
+	proxy, err := newProxyFunc(config.ProxyURL)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := newTLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var transport http.RoundTripper = &http.Transport{
+		Proxy:           proxy,
+		TLSClientConfig: tlsConfig,
+	}
+	if config.RecordFile != "" {
+		transport = newRecordingTransport(transport, config.RecordFile)
+	}
+
+	client := &BaseClient{
+		Endpoint:       config.Endpoint,
+		Username:       config.Username,
+		Password:       config.Password,
+		URL:            url,
+		RequestTimeout: config.RequestTimeout,
+		MaxRetries:     config.MaxRetries,
+		RetryWaitMin:   config.RetryWaitMin,
+		RetryWaitMax:   config.RetryWaitMax,
+		slots:          newSlots(config.MaxConcurrentRequests),
+		limiter:        newLimiter(config.RequestsPerSecond),
+		cache:          newReadCache(config.ReadCache),
+		Client:         &http.Client{Transport: transport},
+	}
+	client.batcher = newBatcher(client, config.BatchWindow, config.MaxBatchSize)
+
+	return client, nil
 }
 
 // XML API request structures - common to all clients
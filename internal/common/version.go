@@ -0,0 +1,59 @@
+package common
+
+import (
+	"strconv"
+	"strings"
+)
+
+// APIVersion returns the XML API version reported by the appliance in its
+// most recent response, or an empty string before the first response
+func (c *BaseClient) APIVersion() string {
+	version, _ := c.apiVersion.Load().(string)
+	return version
+}
+
+// recordAPIVersion remembers the version of a response
+func (c *BaseClient) recordAPIVersion(response *Response) {
+	if response.APIVersion != "" {
+		c.apiVersion.Store(response.APIVersion)
+	}
+}
+
+// APIVersionAtLeast reports whether version, e.g. "1905.1", is minimum or
+// newer. Versions that cannot be parsed count as new enough, so an
+// unexpected format never blocks a configuration.
+func APIVersionAtLeast(version, minimum string) bool {
+	have, ok := parseAPIVersion(version)
+	if !ok {
+		return true
+	}
+	want, ok := parseAPIVersion(minimum)
+	if !ok {
+		return true
+	}
+	for i := range want {
+		if i >= len(have) {
+			return false
+		}
+		if have[i] != want[i] {
+			return have[i] > want[i]
+		}
+	}
+	return true
+}
+
+func parseAPIVersion(version string) ([]int, bool) {
+	if version == "" {
+		return nil, false
+	}
+	parts := strings.Split(strings.TrimSpace(version), ".")
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, false
+		}
+		numbers[i] = n
+	}
+	return numbers, true
+}
@@ -0,0 +1,23 @@
+package common
+
+import "testing"
+
+func TestAPIVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		version, minimum string
+		want             bool
+	}{
+		{"1800.1", "1800.1", true},
+		{"1905.1", "1800.1", true},
+		{"1800.2", "1800.1", true},
+		{"1700.1", "1800.1", false},
+		{"1800", "1800.1", false},
+		{"", "1800.1", true},
+		{"unknown", "1800.1", true},
+	}
+	for _, tt := range tests {
+		if got := APIVersionAtLeast(tt.version, tt.minimum); got != tt.want {
+			t.Errorf("APIVersionAtLeast(%q, %q) = %v, want %v", tt.version, tt.minimum, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package firewallrule
+
+import (
+	"context"
+	"encoding/xml"
+	"reflect"
+	"testing"
+
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/common"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/xmlapitest"
+)
+
+func FuzzReadFirewallRule(f *testing.F) {
+	for _, seed := range []string{
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<FirewallRule transactionid=""><Name>allow-web</Name><Status>Enable</Status><Position>After</Position>` +
+			`<PolicyType>Network</PolicyType><After><Name>default</Name></After><NetworkPolicy><Action>Accept</Action>` +
+			`<SourceZones><Zone>LAN</Zone></SourceZones><DestinationZones><Zone>WAN</Zone></DestinationZones>` +
+			`<DestinationNetworks><Network>web</Network></DestinationNetworks></NetworkPolicy></FirewallRule></Response>`,
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<FirewallRule transactionid=""><Name>allow-web</Name><PolicyType>User</PolicyType><UserPolicy/></FirewallRule></Response>`,
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<FirewallRule><Status>No. of records Zero.</Status></FirewallRule></Response>`,
+		`<Response><Login><status>Authentication Failure</status></Login></Response>`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		rule, err := NewClient(xmlapitest.NewBodyClient(t, body)).ReadFirewallRule(context.Background(), "allow-web")
+		if err != nil || rule == nil {
+			return
+		}
+		if rule.Name != "allow-web" {
+			t.Errorf("read firewall rule %q, want allow-web", rule.Name)
+		}
+	})
+}
+
+func FuzzEncodeFirewallRule(f *testing.F) {
+	f.Add("allow-web", "Allow web", "Accept", "LAN", "web", "Enable")
+	f.Add(`a&b`, `<Description>`, `]]><![CDATA[`, `</Zone><Zone>evil`, "\r\n", `"quoted"`)
+
+	f.Fuzz(func(t *testing.T, name, description, action, zone, network, flag string) {
+		want := FirewallRule{
+			XMLName:     xml.Name{Local: EntityTypeFirewallRule},
+			Name:        name,
+			Description: description,
+			IPFamily:    "IPv4",
+			Status:      flag,
+			Position:    "After",
+			PolicyType:  "Network",
+			After:       &RulePosition{Name: network},
+			NetworkPolicy: &NetworkPolicy{
+				Action:                        action,
+				LogTraffic:                    flag,
+				SkipLocalDestined:             flag,
+				Schedule:                      description,
+				SourceZones:                   &ZoneList{Zones: []string{zone}},
+				DestinationZones:              &ZoneList{Zones: []string{zone, network}},
+				SourceNetworks:                &NetworkList{Networks: []string{network}},
+				DestinationNetworks:           &NetworkList{Networks: []string{network, zone}},
+				Services:                      &ServiceList{Services: []string{description}},
+				DSCPMarking:                   "-1",
+				WebFilter:                     description,
+				WebCategoryBaseQoSPolicy:      description,
+				BlockQuickQuic:                flag,
+				ScanVirus:                     flag,
+				ZeroDayProtection:             flag,
+				ProxyMode:                     flag,
+				DecryptHTTPS:                  flag,
+				ApplicationControl:            description,
+				ApplicationBaseQoSPolicy:      flag,
+				IntrusionPrevention:           description,
+				TrafficShappingPolicy:         description,
+				ScanSMTP:                      flag,
+				ScanSMTPS:                     flag,
+				ScanIMAP:                      flag,
+				ScanIMAPS:                     flag,
+				ScanPOP3:                      flag,
+				ScanPOP3S:                     flag,
+				ScanFTP:                       flag,
+				SourceSecurityHeartbeat:       flag,
+				MinimumSourceHBPermitted:      flag,
+				DestSecurityHeartbeat:         flag,
+				MinimumDestinationHBPermitted: flag,
+			},
+			UserPolicy: &UserPolicy{
+				NetworkPolicy: NetworkPolicy{
+					Action:           action,
+					SourceZones:      &ZoneList{Zones: []string{zone}},
+					DestinationZones: &ZoneList{Zones: []string{network}},
+				},
+				Identity:          &IdentityList{Members: []string{network, description}},
+				MatchIdentity:     flag,
+				ShowCaptivePortal: flag,
+				DataAccounting:    flag,
+			},
+		}
+		data, err := common.EncodeRequest(common.OperationAdd, common.LoginXML{}, &want)
+		if err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+		rules := xmlapitest.DecodeSet[FirewallRule](t, data, EntityTypeFirewallRule)
+		if len(rules) != 1 {
+			t.Fatalf("request %s carries %d firewall rules", data, len(rules))
+		}
+		if xmlapitest.XMLText(name, description, action, zone, network, flag) && !reflect.DeepEqual(rules[0], want) {
+			t.Errorf("round trip = %+v, want %+v", rules[0], want)
+		}
+	})
+}
+
+func TestFirewallRuleClientEscaping(t *testing.T) {
+	ctx := context.Background()
+	client := NewClient(xmlapitest.NewEchoClient(t))
+
+	for _, name := range xmlapitest.HostileNames {
+		t.Run(name, func(t *testing.T) {
+			want := &FirewallRule{
+				XMLName:     xml.Name{Local: EntityTypeFirewallRule},
+				Name:        name,
+				Description: "desc " + name,
+				IPFamily:    "IPv4",
+				Status:      "Enable",
+				Position:    "After",
+				PolicyType:  "Network",
+				After:       &RulePosition{Name: name + " & before"},
+				NetworkPolicy: &NetworkPolicy{
+					Action:              "Accept",
+					LogTraffic:          "Disable",
+					SkipLocalDestined:   "Disable",
+					Schedule:            "All The Time",
+					SourceZones:         &ZoneList{Zones: []string{"LAN"}},
+					DestinationZones:    &ZoneList{Zones: []string{"WAN"}},
+					SourceNetworks:      &NetworkList{Networks: []string{name}},
+					DestinationNetworks: &NetworkList{Networks: []string{"<Any>"}},
+				},
+			}
+			if err := client.CreateFirewallRule(ctx, want); err != nil {
+				t.Fatalf("create: %v", err)
+			}
+			got, err := client.ReadFirewallRule(ctx, name)
+			if err != nil || got == nil {
+				t.Fatalf("read: %v, %v", got, err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("round trip mismatch:\n got  %#v\n want %#v", got, want)
+			}
+
+			if err := client.DeleteFirewallRule(ctx, name); err != nil {
+				t.Fatalf("delete: %v", err)
+			}
+			if got, err := client.ReadFirewallRule(ctx, name); got != nil || err != nil {
+				t.Errorf("read after delete = %#v, %v", got, err)
+			}
+		})
+	}
+}
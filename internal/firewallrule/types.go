@@ -9,14 +9,29 @@ type FirewallRule struct {
 	Description         string          `xml:"Description"`
 	IPFamily            string          `xml:"IPFamily"`
 	Status              string          `xml:"Status"`
-	Position            string          `xml:"Position"`
+	Position            string          `xml:"Position,omitempty"`
 	PolicyType          string          `xml:"PolicyType"`
 	After               *RulePosition   `xml:"After,omitempty"`
 	Before              *RulePosition   `xml:"Before,omitempty"`
 	NetworkPolicy       *NetworkPolicy  `xml:"NetworkPolicy,omitempty"`
+	UserPolicy          *UserPolicy     `xml:"UserPolicy,omitempty"`
 	TransactionID       string          `xml:"transactionid,attr,omitempty"`
 }
 
+// Policy types of a rule
+const (
+	PolicyTypeNetwork = "Network"
+	PolicyTypeUser    = "User"
+)
+
+// Positions of a rule in the rule list
+const (
+	PositionTop    = "Top"
+	PositionBottom = "Bottom"
+	PositionAfter  = "After"
+	PositionBefore = "Before"
+)
+
 // RulePosition specifies the position relative to another rule
 type RulePosition struct {
 	Name string `xml:"Name"`
@@ -32,6 +47,7 @@ type NetworkPolicy struct {
 	DestinationZones             *ZoneList         `xml:"DestinationZones"`
 	SourceNetworks               *NetworkList      `xml:"SourceNetworks,omitempty"`
 	DestinationNetworks          *NetworkList      `xml:"DestinationNetworks,omitempty"`
+	Services                     *ServiceList      `xml:"Services,omitempty"`
 	DSCPMarking                  string            `xml:"DSCPMarking,omitempty"`
 	WebFilter                    string            `xml:"WebFilter,omitempty"`
 	WebCategoryBaseQoSPolicy     string            `xml:"WebCategoryBaseQoSPolicy,omitempty"`
@@ -57,6 +73,21 @@ type NetworkPolicy struct {
 	MinimumDestinationHBPermitted string           `xml:"MinimumDestinationHBPermitted,omitempty"`
 }
 
+// UserPolicy contains the settings of an identity-based rule. It has the
+// settings of a network policy and matches the identities of the users.
+type UserPolicy struct {
+	NetworkPolicy
+	Identity          *IdentityList `xml:"Identity,omitempty"`
+	MatchIdentity     string        `xml:"MatchIdentity,omitempty"`
+	ShowCaptivePortal string        `xml:"ShowCaptivePortal,omitempty"`
+	DataAccounting    string        `xml:"DataAccounting,omitempty"`
+}
+
+// IdentityList contains the users and groups a rule matches
+type IdentityList struct {
+	Members []string `xml:"Member"`
+}
+
 // ZoneList contains a list of zones
 type ZoneList struct {
 	Zones []string `xml:"Zone"`
@@ -67,3 +98,17 @@ type NetworkList struct {
 	Networks []string `xml:"Network"`
 }
 
+// ServiceList contains a list of services
+type ServiceList struct {
+	Services []string `xml:"Service"`
+}
+
+
+// EntityTypeFirewallRule is the XML API tag of firewall rules
+const EntityTypeFirewallRule = "FirewallRule"
+
+// EntityType returns the XML API tag of the firewall rule
+func (r FirewallRule) EntityType() string { return EntityTypeFirewallRule }
+
+// EntityName returns the name of the firewall rule
+func (r FirewallRule) EntityName() string { return r.Name }
@@ -0,0 +1,208 @@
+package fqdnhost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/common"
+)
+
+// Client for FQDNHost and FQDNHostGroup operations
+type Client struct {
+	*common.BaseClient
+}
+
+// NewClient creates a new FQDNHost client
+func NewClient(baseClient *common.BaseClient) *Client {
+	return &Client{
+		BaseClient: baseClient,
+	}
+}
+
+// CreateFQDNHost implements single FQDN host creation
+func (c *Client) CreateFQDNHost(ctx context.Context, host *FQDNHost) error {
+	host.TransactionID = ""
+
+	if _, err := c.Execute(ctx, common.OperationAdd, host); err != nil {
+		return fmt.Errorf("error creating FQDN host: %w", err)
+	}
+
+	return nil
+}
+
+// ReadFQDNHost implements FQDN host reading
+func (c *Client) ReadFQDNHost(ctx context.Context, name string) (*FQDNHost, error) {
+	response, err := c.Execute(ctx, common.OperationGet, common.Ref(EntityTypeFQDNHost, name))
+	if err != nil {
+		var notFound *common.NotFoundError
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading FQDN host: %w", err)
+	}
+
+	hosts, err := common.DecodeEntities[FQDNHost](response, EntityTypeFQDNHost)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the FQDN host with the matching name
+	for i := range hosts {
+		if hosts[i].Name == name {
+			normalizeFQDNHost(&hosts[i])
+			return &hosts[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// ListFQDNHosts reads every FQDN host with an unfiltered Get
+func (c *Client) ListFQDNHosts(ctx context.Context) ([]FQDNHost, error) {
+	response, err := c.Execute(ctx, common.OperationGet, common.Ref(EntityTypeFQDNHost, ""))
+	if err != nil {
+		var notFound *common.NotFoundError
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error listing FQDN hosts: %w", err)
+	}
+
+	hosts, err := common.DecodeEntities[FQDNHost](response, EntityTypeFQDNHost)
+	if err != nil {
+		return nil, err
+	}
+	for i := range hosts {
+		normalizeFQDNHost(&hosts[i])
+	}
+	return hosts, nil
+}
+
+// normalizeFQDNHost sorts and deduplicates the groups of the host
+func normalizeFQDNHost(host *FQDNHost) {
+	if host.FQDNHostGroupList == nil {
+		host.FQDNHostGroupList = &FQDNHostGroupList{}
+	}
+	host.FQDNHostGroupList.FQDNHostGroups = sortedUnique(host.FQDNHostGroupList.FQDNHostGroups)
+}
+
+// UpdateFQDNHost implements FQDN host updating
+func (c *Client) UpdateFQDNHost(ctx context.Context, host *FQDNHost) error {
+	host.TransactionID = ""
+
+	if _, err := c.Execute(ctx, common.OperationUpdate, host); err != nil {
+		return fmt.Errorf("error updating FQDN host: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteFQDNHost implements FQDN host deletion
+func (c *Client) DeleteFQDNHost(ctx context.Context, name string) error {
+	if _, err := c.Execute(ctx, common.OperationRemove, common.Ref(EntityTypeFQDNHost, name)); err != nil {
+		return fmt.Errorf("error deleting FQDN host: %w", err)
+	}
+
+	return nil
+}
+
+// CreateFQDNHostGroup implements single FQDN host group creation
+func (c *Client) CreateFQDNHostGroup(ctx context.Context, group *FQDNHostGroup) error {
+	group.TransactionID = ""
+
+	if _, err := c.Execute(ctx, common.OperationAdd, group); err != nil {
+		return fmt.Errorf("error creating FQDN host group: %w", err)
+	}
+
+	return nil
+}
+
+// ReadFQDNHostGroup implements FQDN host group reading
+func (c *Client) ReadFQDNHostGroup(ctx context.Context, name string) (*FQDNHostGroup, error) {
+	response, err := c.Execute(ctx, common.OperationGet, common.Ref(EntityTypeFQDNHostGroup, name))
+	if err != nil {
+		var notFound *common.NotFoundError
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading FQDN host group: %w", err)
+	}
+
+	groups, err := common.DecodeEntities[FQDNHostGroup](response, EntityTypeFQDNHostGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the FQDN host group with the matching name
+	for i := range groups {
+		if groups[i].Name == name {
+			normalizeFQDNHostGroup(&groups[i])
+			return &groups[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// ListFQDNHostGroups reads every FQDN host group with an unfiltered Get
+func (c *Client) ListFQDNHostGroups(ctx context.Context) ([]FQDNHostGroup, error) {
+	response, err := c.Execute(ctx, common.OperationGet, common.Ref(EntityTypeFQDNHostGroup, ""))
+	if err != nil {
+		var notFound *common.NotFoundError
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error listing FQDN host groups: %w", err)
+	}
+
+	groups, err := common.DecodeEntities[FQDNHostGroup](response, EntityTypeFQDNHostGroup)
+	if err != nil {
+		return nil, err
+	}
+	for i := range groups {
+		normalizeFQDNHostGroup(&groups[i])
+	}
+	return groups, nil
+}
+
+// normalizeFQDNHostGroup sorts and deduplicates the hosts of the group
+func normalizeFQDNHostGroup(group *FQDNHostGroup) {
+	if group.FQDNHostList == nil {
+		group.FQDNHostList = &FQDNHostList{}
+	}
+	group.FQDNHostList.FQDNHosts = sortedUnique(group.FQDNHostList.FQDNHosts)
+}
+
+// sortedUnique returns the names sorted and without duplicates, as the
+// appliance keeps neither the order nor the uniqueness of member lists
+func sortedUnique(names []string) []string {
+	names = slices.Clone(names)
+	slices.Sort(names)
+	names = slices.Compact(names)
+	if names == nil {
+		return []string{}
+	}
+	return names
+}
+
+// UpdateFQDNHostGroup implements FQDN host group updating
+func (c *Client) UpdateFQDNHostGroup(ctx context.Context, group *FQDNHostGroup) error {
+	group.TransactionID = ""
+
+	if _, err := c.Execute(ctx, common.OperationUpdate, group); err != nil {
+		return fmt.Errorf("error updating FQDN host group: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteFQDNHostGroup implements FQDN host group deletion
+func (c *Client) DeleteFQDNHostGroup(ctx context.Context, name string) error {
+	if _, err := c.Execute(ctx, common.OperationRemove, common.Ref(EntityTypeFQDNHostGroup, name)); err != nil {
+		return fmt.Errorf("error deleting FQDN host group: %w", err)
+	}
+
+	return nil
+}
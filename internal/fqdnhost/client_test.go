@@ -0,0 +1,205 @@
+package fqdnhost
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/common"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/xmlapitest"
+)
+
+func FuzzReadFQDNHost(f *testing.F) {
+	for _, seed := range []string{
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<FQDNHost transactionid=""><Name>web</Name><FQDN>*.example.com</FQDN>` +
+			`<FQDNHostGroupList><FQDNHostGroup>saas</FQDNHostGroup><FQDNHostGroup>cdn</FQDNHostGroup><FQDNHostGroup>saas</FQDNHostGroup></FQDNHostGroupList>` +
+			`</FQDNHost></Response>`,
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<FQDNHost transactionid=""><Name>web</Name><FQDN>www.example.com</FQDN></FQDNHost></Response>`,
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<FQDNHost><Status>No. of records Zero.</Status></FQDNHost></Response>`,
+		`<Response><Login><status>Authentication Failure</status></Login></Response>`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		host, err := NewClient(xmlapitest.NewBodyClient(t, body)).ReadFQDNHost(context.Background(), "web")
+		if err != nil || host == nil {
+			return
+		}
+		if host.Name != "web" {
+			t.Errorf("read FQDN host %q, want web", host.Name)
+		}
+		if host.FQDNHostGroupList == nil {
+			t.Fatal("group list is nil")
+		}
+		groups := host.FQDNHostGroupList.FQDNHostGroups
+		if !slices.IsSorted(groups) || len(slices.Compact(slices.Clone(groups))) != len(groups) {
+			t.Errorf("groups %q are not sorted and unique", groups)
+		}
+	})
+}
+
+func FuzzReadFQDNHostGroup(f *testing.F) {
+	for _, seed := range []string{
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<FQDNHostGroup transactionid=""><Name>web</Name>` +
+			`<FQDNHostList><FQDNHost>b</FQDNHost><FQDNHost>a</FQDNHost><FQDNHost>b</FQDNHost></FQDNHostList></FQDNHostGroup></Response>`,
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<FQDNHostGroup transactionid=""><Name>web</Name><FQDNHostList/></FQDNHostGroup></Response>`,
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<FQDNHostGroup><Status>No. of records Zero.</Status></FQDNHostGroup></Response>`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		group, err := NewClient(xmlapitest.NewBodyClient(t, body)).ReadFQDNHostGroup(context.Background(), "web")
+		if err != nil || group == nil {
+			return
+		}
+		if group.Name != "web" {
+			t.Errorf("read FQDN host group %q, want web", group.Name)
+		}
+		if group.FQDNHostList == nil {
+			t.Fatal("host list is nil")
+		}
+		hosts := group.FQDNHostList.FQDNHosts
+		if !slices.IsSorted(hosts) || len(slices.Compact(slices.Clone(hosts))) != len(hosts) {
+			t.Errorf("hosts %q are not sorted and unique", hosts)
+		}
+	})
+}
+
+func FuzzEncodeFQDNHost(f *testing.F) {
+	f.Add("web", "Web servers", "*.example.com", "saas")
+	f.Add(`a&b`, `<Description>`, `]]><![CDATA[`, `</FQDNHostGroup><FQDNHostGroup>evil`)
+
+	f.Fuzz(func(t *testing.T, name, description, fqdn, group string) {
+		want := FQDNHost{
+			Name:              name,
+			Description:       description,
+			FQDN:              fqdn,
+			FQDNHostGroupList: &FQDNHostGroupList{FQDNHostGroups: []string{group}},
+		}
+		data, err := common.EncodeRequest(common.OperationAdd, common.LoginXML{}, &want)
+		if err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+		hosts := xmlapitest.DecodeSet[FQDNHost](t, data, EntityTypeFQDNHost)
+		if len(hosts) != 1 {
+			t.Fatalf("request %s carries %d FQDN hosts", data, len(hosts))
+		}
+		if xmlapitest.XMLText(name, description, fqdn, group) && !reflect.DeepEqual(hosts[0], want) {
+			t.Errorf("round trip = %+v, want %+v", hosts[0], want)
+		}
+	})
+}
+
+func FuzzEncodeFQDNHostGroup(f *testing.F) {
+	f.Add("saas", "SaaS hosts", "web", "cdn")
+	f.Add(`a&b`, `<Description>`, `</FQDNHost><FQDNHost>evil`, "\r\n")
+
+	f.Fuzz(func(t *testing.T, name, description, host1, host2 string) {
+		want := FQDNHostGroup{
+			Name:         name,
+			Description:  description,
+			FQDNHostList: &FQDNHostList{FQDNHosts: []string{host1, host2}},
+		}
+		data, err := common.EncodeRequest(common.OperationAdd, common.LoginXML{}, &want)
+		if err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+		groups := xmlapitest.DecodeSet[FQDNHostGroup](t, data, EntityTypeFQDNHostGroup)
+		if len(groups) != 1 {
+			t.Fatalf("request %s carries %d FQDN host groups", data, len(groups))
+		}
+		if xmlapitest.XMLText(name, description, host1, host2) && !reflect.DeepEqual(groups[0], want) {
+			t.Errorf("round trip = %+v, want %+v", groups[0], want)
+		}
+	})
+}
+
+func TestFQDNHostClientEscaping(t *testing.T) {
+	ctx := context.Background()
+	client := NewClient(xmlapitest.NewEchoClient(t))
+
+	for _, name := range xmlapitest.HostileNames {
+		t.Run(name, func(t *testing.T) {
+			want := &FQDNHost{
+				Name:              name,
+				Description:       "desc " + name,
+				FQDN:              "www.example.com",
+				FQDNHostGroupList: &FQDNHostGroupList{FQDNHostGroups: []string{name}},
+			}
+			if err := client.CreateFQDNHost(ctx, want); err != nil {
+				t.Fatalf("create: %v", err)
+			}
+			got, err := client.ReadFQDNHost(ctx, name)
+			if err != nil || got == nil {
+				t.Fatalf("read: %v, %v", got, err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("round trip mismatch:\n got  %#v\n want %#v", got, want)
+			}
+
+			want.Description = "updated " + name
+			if err := client.UpdateFQDNHost(ctx, want); err != nil {
+				t.Fatalf("update: %v", err)
+			}
+			if got, _ := client.ReadFQDNHost(ctx, name); got == nil || got.Description != want.Description {
+				t.Errorf("updated description = %#v, want %q", got, want.Description)
+			}
+
+			if err := client.DeleteFQDNHost(ctx, name); err != nil {
+				t.Fatalf("delete: %v", err)
+			}
+			if got, err := client.ReadFQDNHost(ctx, name); got != nil || err != nil {
+				t.Errorf("read after delete = %#v, %v", got, err)
+			}
+		})
+	}
+}
+
+func TestFQDNHostGroupClientEscaping(t *testing.T) {
+	ctx := context.Background()
+	client := NewClient(xmlapitest.NewEchoClient(t))
+
+	for _, name := range xmlapitest.HostileNames {
+		t.Run(name, func(t *testing.T) {
+			want := &FQDNHostGroup{
+				Name:         name,
+				Description:  "desc " + name,
+				FQDNHostList: &FQDNHostList{FQDNHosts: []string{name}},
+			}
+			if err := client.CreateFQDNHostGroup(ctx, want); err != nil {
+				t.Fatalf("create: %v", err)
+			}
+			got, err := client.ReadFQDNHostGroup(ctx, name)
+			if err != nil || got == nil {
+				t.Fatalf("read: %v, %v", got, err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("round trip mismatch:\n got  %#v\n want %#v", got, want)
+			}
+
+			want.Description = "updated " + name
+			if err := client.UpdateFQDNHostGroup(ctx, want); err != nil {
+				t.Fatalf("update: %v", err)
+			}
+			if got, _ := client.ReadFQDNHostGroup(ctx, name); got == nil || got.Description != want.Description {
+				t.Errorf("updated description = %#v, want %q", got, want.Description)
+			}
+
+			if err := client.DeleteFQDNHostGroup(ctx, name); err != nil {
+				t.Fatalf("delete: %v", err)
+			}
+			if got, err := client.ReadFQDNHostGroup(ctx, name); got != nil || err != nil {
+				t.Errorf("read after delete = %#v, %v", got, err)
+			}
+		})
+	}
+}
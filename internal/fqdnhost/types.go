@@ -0,0 +1,46 @@
+package fqdnhost
+
+// FQDNHost represents the firewall FQDN host model
+type FQDNHost struct {
+	Name              string             `xml:"Name"`
+	Description       string             `xml:"Description"`
+	FQDN              string             `xml:"FQDN"`
+	FQDNHostGroupList *FQDNHostGroupList `xml:"FQDNHostGroupList"`
+	TransactionID     string             `xml:"transactionid,attr"`
+}
+
+// FQDNHostGroupList represents the groups an FQDN host belongs to
+type FQDNHostGroupList struct {
+	FQDNHostGroups []string `xml:"FQDNHostGroup"`
+}
+
+// FQDNHostGroup represents the firewall FQDN host group model
+type FQDNHostGroup struct {
+	Name          string        `xml:"Name"`
+	Description   string        `xml:"Description"`
+	FQDNHostList  *FQDNHostList `xml:"FQDNHostList"`
+	TransactionID string        `xml:"transactionid,attr"`
+}
+
+// FQDNHostList represents the FQDN hosts of a group
+type FQDNHostList struct {
+	FQDNHosts []string `xml:"FQDNHost"`
+}
+
+// EntityTypeFQDNHost is the XML API tag of FQDN host objects
+const EntityTypeFQDNHost = "FQDNHost"
+
+// EntityTypeFQDNHostGroup is the XML API tag of FQDN host group objects
+const EntityTypeFQDNHostGroup = "FQDNHostGroup"
+
+// EntityType returns the XML API tag of the FQDN host
+func (h FQDNHost) EntityType() string { return EntityTypeFQDNHost }
+
+// EntityName returns the name of the FQDN host
+func (h FQDNHost) EntityName() string { return h.Name }
+
+// EntityType returns the XML API tag of the FQDN host group
+func (g FQDNHostGroup) EntityType() string { return EntityTypeFQDNHostGroup }
+
+// EntityName returns the name of the FQDN host group
+func (g FQDNHostGroup) EntityName() string { return g.Name }
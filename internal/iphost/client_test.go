@@ -0,0 +1,113 @@
+package iphost
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/common"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/xmlapitest"
+)
+
+func FuzzReadIPHost(f *testing.F) {
+	for _, seed := range []string{
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<IPHost transactionid=""><Name>web</Name><IPFamily>IPv4</IPFamily><HostType>IP</HostType>` +
+			`<IPAddress>10.0.0.1</IPAddress><HostGroupList><HostGroup>servers</HostGroup><HostGroup>servers</HostGroup></HostGroupList></IPHost></Response>`,
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<IPHost transactionid=""><Name>db</Name></IPHost><IPHost transactionid=""><Name>web</Name><HostType>IPRange</HostType></IPHost></Response>`,
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<IPHost><Status>No. of records Zero.</Status></IPHost></Response>`,
+		`<Response><Login><status>Authentication Failure</status></Login></Response>`,
+		`<Response><Login><status>Authentication Successful</status></Login><IPHost><Name>web`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		host, err := NewClient(xmlapitest.NewBodyClient(t, body)).ReadIPHost(context.Background(), "web")
+		if err != nil || host == nil {
+			return
+		}
+		if host.Name != "web" {
+			t.Errorf("read IP host %q, want web", host.Name)
+		}
+		if host.HostGroupList == nil {
+			t.Error("host group list is nil")
+		}
+	})
+}
+
+func FuzzEncodeIPHost(f *testing.F) {
+	f.Add("web", "IPv4", "IP", "10.0.0.1", "", "servers")
+	f.Add("lan", "IPv4", "Network", "10.0.0.0", "255.255.255.0", "")
+	f.Add(`a&b`, `<IPFamily>`, `]]><![CDATA[`, `</IPHost><IPHost><Name>evil`, "\r\n", `"quoted"`)
+
+	f.Fuzz(func(t *testing.T, name, family, hostType, address, subnet, group string) {
+		want := IPHost{
+			Name:              name,
+			IPFamily:          family,
+			HostType:          hostType,
+			IPAddress:         address,
+			Subnet:            subnet,
+			StartIPAddress:    address,
+			EndIPAddress:      subnet,
+			ListOfIPAddresses: address + "," + subnet,
+			HostGroupList:     &HostGroupList{HostGroups: []string{group}},
+		}
+		data, err := common.EncodeRequest(common.OperationAdd, common.LoginXML{}, &want)
+		if err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+		hosts := xmlapitest.DecodeSet[IPHost](t, data, EntityTypeIPHost)
+		if len(hosts) != 1 {
+			t.Fatalf("request %s carries %d IP hosts", data, len(hosts))
+		}
+		if xmlapitest.XMLText(name, family, hostType, address, subnet, group) && !reflect.DeepEqual(hosts[0], want) {
+			t.Errorf("round trip = %+v, want %+v", hosts[0], want)
+		}
+	})
+}
+
+func TestIPHostClientEscaping(t *testing.T) {
+	ctx := context.Background()
+	client := NewClient(xmlapitest.NewEchoClient(t))
+
+	for _, name := range xmlapitest.HostileNames {
+		t.Run(name, func(t *testing.T) {
+			want := &IPHost{
+				Name:          name,
+				Description:   "desc " + name,
+				IPFamily:      "IPv4",
+				HostType:      "IP",
+				IPAddress:     "10.0.0.1",
+				HostGroupList: &HostGroupList{HostGroups: []string{name}},
+			}
+			if err := client.CreateIPHost(ctx, want); err != nil {
+				t.Fatalf("create: %v", err)
+			}
+			got, err := client.ReadIPHost(ctx, name)
+			if err != nil || got == nil {
+				t.Fatalf("read: %v, %v", got, err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("round trip mismatch:\n got  %#v\n want %#v", got, want)
+			}
+
+			want.Description = "updated " + name
+			if err := client.UpdateIPHost(ctx, want); err != nil {
+				t.Fatalf("update: %v", err)
+			}
+			if got, _ := client.ReadIPHost(ctx, name); got == nil || got.Description != want.Description {
+				t.Errorf("updated description = %#v, want %q", got, want.Description)
+			}
+
+			if err := client.DeleteIPHost(ctx, name); err != nil {
+				t.Fatalf("delete: %v", err)
+			}
+			if got, err := client.ReadIPHost(ctx, name); got != nil || err != nil {
+				t.Errorf("read after delete = %#v, %v", got, err)
+			}
+		})
+	}
+}
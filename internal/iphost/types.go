@@ -18,4 +18,12 @@ type IPHost struct {
 // HostGroupList represents the host group list in the XML response
 type HostGroupList struct {
 	HostGroups []string `xml:"HostGroup"`
-}
\ No newline at end of file
+}
+// EntityTypeIPHost is the XML API tag of IP host objects
+const EntityTypeIPHost = "IPHost"
+
+// EntityType returns the XML API tag of the IP host
+func (h IPHost) EntityType() string { return EntityTypeIPHost }
+
+// EntityName returns the name of the IP host
+func (h IPHost) EntityName() string { return h.Name }
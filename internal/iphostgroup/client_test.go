@@ -0,0 +1,107 @@
+package iphostgroup
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/common"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/xmlapitest"
+)
+
+func FuzzReadIPHostGroup(f *testing.F) {
+	for _, seed := range []string{
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<IPHostGroup transactionid=""><Name>web</Name><IPFamily>IPv4</IPFamily>` +
+			`<HostList><Host>web1</Host><Host>web2</Host><Host>web1</Host></HostList></IPHostGroup></Response>`,
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<IPHostGroup transactionid=""><Name>web</Name><HostList/></IPHostGroup></Response>`,
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<IPHostGroup><Status>No. of records Zero.</Status></IPHostGroup></Response>`,
+		`<Response><Login><status>Authentication Failure</status></Login></Response>`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		group, err := NewClient(xmlapitest.NewBodyClient(t, body)).ReadIPHostGroup(context.Background(), "web")
+		if err != nil || group == nil {
+			return
+		}
+		if group.Name != "web" {
+			t.Errorf("read IP host group %q, want web", group.Name)
+		}
+		if group.HostList == nil {
+			t.Fatal("host list is nil")
+		}
+		seen := map[string]bool{}
+		for _, host := range group.HostList.Hosts {
+			if seen[host] {
+				t.Errorf("host %q listed twice", host)
+			}
+			seen[host] = true
+		}
+	})
+}
+
+func FuzzEncodeIPHostGroup(f *testing.F) {
+	f.Add("web", "Web servers", "IPv4", "web1", "web2")
+	f.Add(`a&b`, `<Description>`, `]]><![CDATA[`, `</Host><Host>evil`, "\r\n")
+
+	f.Fuzz(func(t *testing.T, name, description, family, host1, host2 string) {
+		want := IPHostGroup{
+			Name:        name,
+			Description: description,
+			IPFamily:    family,
+			HostList:    &HostList{Hosts: []string{host1, host2}},
+		}
+		data, err := common.EncodeRequest(common.OperationAdd, common.LoginXML{}, &want)
+		if err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+		groups := xmlapitest.DecodeSet[IPHostGroup](t, data, EntityTypeIPHostGroup)
+		if len(groups) != 1 {
+			t.Fatalf("request %s carries %d IP host groups", data, len(groups))
+		}
+		if xmlapitest.XMLText(name, description, family, host1, host2) && !reflect.DeepEqual(groups[0], want) {
+			t.Errorf("round trip = %+v, want %+v", groups[0], want)
+		}
+	})
+}
+
+func TestIPHostGroupClientEscaping(t *testing.T) {
+	ctx := context.Background()
+	client := NewClient(xmlapitest.NewEchoClient(t))
+
+	for _, name := range xmlapitest.HostileNames {
+		t.Run(name, func(t *testing.T) {
+			want := &IPHostGroup{
+				Name:        name,
+				Description: "desc " + name,
+				IPFamily:    "IPv4",
+				HostList:    &HostList{Hosts: []string{name, "plain"}},
+			}
+			if err := client.CreateIPHostGroup(ctx, want); err != nil {
+				t.Fatalf("create: %v", err)
+			}
+			got, err := client.ReadIPHostGroup(ctx, name)
+			if err != nil || got == nil {
+				t.Fatalf("read: %v, %v", got, err)
+			}
+			// Reads deduplicate the member list, which does not keep its order
+			sort.Strings(got.HostList.Hosts)
+			sort.Strings(want.HostList.Hosts)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("round trip mismatch:\n got  %#v\n want %#v", got, want)
+			}
+
+			if err := client.DeleteIPHostGroup(ctx, name); err != nil {
+				t.Fatalf("delete: %v", err)
+			}
+			if got, err := client.ReadIPHostGroup(ctx, name); got != nil || err != nil {
+				t.Errorf("read after delete = %#v, %v", got, err)
+			}
+		})
+	}
+}
@@ -14,3 +14,12 @@ type HostList struct {
 	Hosts []string `xml:"Host"`
 }
 
+
+// EntityTypeIPHostGroup is the XML API tag of IP host group objects
+const EntityTypeIPHostGroup = "IPHostGroup"
+
+// EntityType returns the XML API tag of the IP host group
+func (g IPHostGroup) EntityType() string { return EntityTypeIPHostGroup }
+
+// EntityName returns the name of the IP host group
+func (g IPHostGroup) EntityName() string { return g.Name }
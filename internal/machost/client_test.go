@@ -0,0 +1,109 @@
+package machost
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/common"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/xmlapitest"
+)
+
+func FuzzReadMACHost(f *testing.F) {
+	for _, seed := range []string{
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<MACHost transactionid=""><Name>printers</Name><Type>MACLIST</Type>` +
+			`<MACList><MACAddress>00:16:3e:00:00:01</MACAddress><MACAddress>00:16:3e:00:00:02</MACAddress></MACList>` +
+			`<MACHostGroupList><MACHostGroup>office</MACHostGroup><MACHostGroup>lab</MACHostGroup><MACHostGroup>office</MACHostGroup></MACHostGroupList>` +
+			`</MACHost></Response>`,
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<MACHost transactionid=""><Name>printers</Name><Type>MACAddress</Type><MACAddress>00:16:3e:00:00:01</MACAddress></MACHost></Response>`,
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<MACHost><Status>No. of records Zero.</Status></MACHost></Response>`,
+		`<Response><Login><status>Authentication Failure</status></Login></Response>`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		host, err := NewClient(xmlapitest.NewBodyClient(t, body)).ReadMACHost(context.Background(), "printers")
+		if err != nil || host == nil {
+			return
+		}
+		if host.Name != "printers" {
+			t.Errorf("read MAC host %q, want printers", host.Name)
+		}
+		if host.Type != "MACAddress" && host.MACAddress != "" {
+			t.Errorf("%s host has MAC address %q", host.Type, host.MACAddress)
+		}
+		if host.Type != "MACLIST" && len(host.ListOfMACAddresses) != 0 {
+			t.Errorf("%s host has MAC list %v", host.Type, host.ListOfMACAddresses)
+		}
+		if host.MACHostGroupList == nil {
+			t.Fatal("group list is nil")
+		}
+		groups := host.MACHostGroupList.MACHostGroups
+		if !slices.IsSorted(groups) || len(slices.Compact(slices.Clone(groups))) != len(groups) {
+			t.Errorf("groups %q are not sorted and unique", groups)
+		}
+	})
+}
+
+func FuzzEncodeMACHost(f *testing.F) {
+	f.Add("printer", "Office printer", "00:16:3e:00:00:01", "00:16:3e:00:00:02", false)
+	f.Add(`a&b`, `<Description>`, `]]><![CDATA[`, `</MACAddress><MACAddress>evil`, true)
+
+	f.Fuzz(func(t *testing.T, name, description, mac1, mac2 string, list bool) {
+		want := MACHost{Name: name, Description: description, Type: "MACAddress", MACAddress: mac1}
+		if list {
+			want = MACHost{Name: name, Description: description, Type: "MACLIST", MACList: &MACList{MACAddresses: []string{mac1, mac2}}}
+		}
+		data, err := common.EncodeRequest(common.OperationAdd, common.LoginXML{}, &want)
+		if err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+		hosts := xmlapitest.DecodeSet[MACHost](t, data, EntityTypeMACHost)
+		if len(hosts) != 1 {
+			t.Fatalf("request %s carries %d MAC hosts", data, len(hosts))
+		}
+		// An empty MACAddress is omitted and cannot be told apart from none
+		if xmlapitest.XMLText(name, description, mac1, mac2) && (list || mac1 != "") && !reflect.DeepEqual(hosts[0], want) {
+			t.Errorf("round trip = %+v, want %+v", hosts[0], want)
+		}
+	})
+}
+
+func TestMACHostClientEscaping(t *testing.T) {
+	ctx := context.Background()
+	client := NewClient(xmlapitest.NewEchoClient(t))
+
+	for _, name := range xmlapitest.HostileNames {
+		t.Run(name, func(t *testing.T) {
+			want := &MACHost{
+				Name:               name,
+				Description:        "desc " + name,
+				Type:               "MACLIST",
+				ListOfMACAddresses: []string{"00:16:76:49:33:CE", "00:16:76:49:33:CF"},
+				MACHostGroupList:   &MACHostGroupList{MACHostGroups: []string{name}},
+			}
+			if err := client.CreateMACHost(ctx, want); err != nil {
+				t.Fatalf("create: %v", err)
+			}
+			got, err := client.ReadMACHost(ctx, name)
+			if err != nil || got == nil {
+				t.Fatalf("read: %v, %v", got, err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("round trip mismatch:\n got  %#v\n want %#v", got, want)
+			}
+
+			if err := client.DeleteMACHost(ctx, name); err != nil {
+				t.Fatalf("delete: %v", err)
+			}
+			if got, err := client.ReadMACHost(ctx, name); got != nil || err != nil {
+				t.Errorf("read after delete = %#v, %v", got, err)
+			}
+		})
+	}
+}
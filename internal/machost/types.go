@@ -1,10 +1,31 @@
 package machost
 
 type MACHost struct {
-	Name                string   `xml:"Name"`
-	Description         string   `xml:"Description"`
-	Type                string   `xml:"Type"`
-	MACAddress          string   `xml:"MACAddress,omitempty"`
-	ListOfMACAddresses  []string `xml:"-"` // This will be populated from the MACList structure
-	TransactionID       string   `xml:"transactionid,attr"`
-}
\ No newline at end of file
+	Name               string            `xml:"Name"`
+	Description        string            `xml:"Description"`
+	Type               string            `xml:"Type"`
+	MACAddress         string            `xml:"MACAddress,omitempty"`
+	MACList            *MACList          `xml:"MACList,omitempty"`
+	ListOfMACAddresses []string          `xml:"-"` // This will be populated from the MACList structure
+	MACHostGroupList   *MACHostGroupList `xml:"MACHostGroupList"`
+	TransactionID      string            `xml:"transactionid,attr"`
+}
+
+// MACHostGroupList represents the groups a MAC host belongs to
+type MACHostGroupList struct {
+	MACHostGroups []string `xml:"MACHostGroup"`
+}
+
+// MACList represents the list of MAC addresses of a MACLIST host
+type MACList struct {
+	MACAddresses []string `xml:"MACAddress"`
+}
+
+// EntityTypeMACHost is the XML API tag of MAC host objects
+const EntityTypeMACHost = "MACHost"
+
+// EntityType returns the XML API tag of the MAC host
+func (h MACHost) EntityType() string { return EntityTypeMACHost }
+
+// EntityName returns the name of the MAC host
+func (h MACHost) EntityName() string { return h.Name }
@@ -0,0 +1,112 @@
+package machostgroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/common"
+)
+
+// Client for MACHostGroup operations
+type Client struct {
+	*common.BaseClient
+}
+
+// NewClient creates a new MACHostGroup client
+func NewClient(baseClient *common.BaseClient) *Client {
+	return &Client{
+		BaseClient: baseClient,
+	}
+}
+
+// CreateMACHostGroup implements single MAC host group creation
+func (c *Client) CreateMACHostGroup(ctx context.Context, group *MACHostGroup) error {
+	group.TransactionID = ""
+
+	if _, err := c.Execute(ctx, common.OperationAdd, group); err != nil {
+		return fmt.Errorf("error creating MAC host group: %w", err)
+	}
+
+	return nil
+}
+
+// ReadMACHostGroup implements MAC host group reading
+func (c *Client) ReadMACHostGroup(ctx context.Context, name string) (*MACHostGroup, error) {
+	response, err := c.Execute(ctx, common.OperationGet, common.Ref(EntityTypeMACHostGroup, name))
+	if err != nil {
+		var notFound *common.NotFoundError
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading MAC host group: %w", err)
+	}
+
+	groups, err := common.DecodeEntities[MACHostGroup](response, EntityTypeMACHostGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the MAC host group with the matching name
+	for i := range groups {
+		if groups[i].Name == name {
+			normalizeMACHostGroup(&groups[i])
+			return &groups[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// ListMACHostGroups reads every MAC host group with an unfiltered Get
+func (c *Client) ListMACHostGroups(ctx context.Context) ([]MACHostGroup, error) {
+	response, err := c.Execute(ctx, common.OperationGet, common.Ref(EntityTypeMACHostGroup, ""))
+	if err != nil {
+		var notFound *common.NotFoundError
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error listing MAC host groups: %w", err)
+	}
+
+	groups, err := common.DecodeEntities[MACHostGroup](response, EntityTypeMACHostGroup)
+	if err != nil {
+		return nil, err
+	}
+	for i := range groups {
+		normalizeMACHostGroup(&groups[i])
+	}
+	return groups, nil
+}
+
+// normalizeMACHostGroup sorts and deduplicates the hosts of the group, as
+// the appliance keeps neither their order nor their uniqueness
+func normalizeMACHostGroup(group *MACHostGroup) {
+	if group.MACHostList == nil {
+		group.MACHostList = &MACHostList{}
+	}
+	hosts := slices.Clone(group.MACHostList.MACHosts)
+	slices.Sort(hosts)
+	group.MACHostList.MACHosts = append([]string{}, slices.Compact(hosts)...)
+}
+
+// UpdateMACHostGroup implements MAC host group updating
+func (c *Client) UpdateMACHostGroup(ctx context.Context, group *MACHostGroup) error {
+	group.TransactionID = ""
+
+	if _, err := c.Execute(ctx, common.OperationUpdate, group); err != nil {
+		return fmt.Errorf("error updating MAC host group: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteMACHostGroup implements MAC host group deletion
+func (c *Client) DeleteMACHostGroup(ctx context.Context, name string) error {
+	if _, err := c.Execute(ctx, common.OperationRemove, common.Ref(EntityTypeMACHostGroup, name)); err != nil {
+		return fmt.Errorf("error deleting MAC host group: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package machostgroup
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/common"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/xmlapitest"
+)
+
+func FuzzReadMACHostGroup(f *testing.F) {
+	for _, seed := range []string{
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<MACHostGroup transactionid=""><Name>printers</Name>` +
+			`<MACHostList><MACHost>b</MACHost><MACHost>a</MACHost><MACHost>b</MACHost></MACHostList></MACHostGroup></Response>`,
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<MACHostGroup transactionid=""><Name>printers</Name><MACHostList/></MACHostGroup></Response>`,
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<MACHostGroup><Status>No. of records Zero.</Status></MACHostGroup></Response>`,
+		`<Response><Login><status>Authentication Failure</status></Login></Response>`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		group, err := NewClient(xmlapitest.NewBodyClient(t, body)).ReadMACHostGroup(context.Background(), "printers")
+		if err != nil || group == nil {
+			return
+		}
+		if group.Name != "printers" {
+			t.Errorf("read MAC host group %q, want printers", group.Name)
+		}
+		if group.MACHostList == nil {
+			t.Fatal("host list is nil")
+		}
+		hosts := group.MACHostList.MACHosts
+		if !slices.IsSorted(hosts) || len(slices.Compact(slices.Clone(hosts))) != len(hosts) {
+			t.Errorf("hosts %q are not sorted and unique", hosts)
+		}
+	})
+}
+
+func FuzzEncodeMACHostGroup(f *testing.F) {
+	f.Add("printers", "Office printers", "printer1", "printer2")
+	f.Add(`a&b`, `<Description>`, `</MACHost><MACHost>evil`, "\r\n")
+
+	f.Fuzz(func(t *testing.T, name, description, host1, host2 string) {
+		want := MACHostGroup{
+			Name:        name,
+			Description: description,
+			MACHostList: &MACHostList{MACHosts: []string{host1, host2}},
+		}
+		data, err := common.EncodeRequest(common.OperationAdd, common.LoginXML{}, &want)
+		if err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+		groups := xmlapitest.DecodeSet[MACHostGroup](t, data, EntityTypeMACHostGroup)
+		if len(groups) != 1 {
+			t.Fatalf("request %s carries %d MAC host groups", data, len(groups))
+		}
+		if xmlapitest.XMLText(name, description, host1, host2) && !reflect.DeepEqual(groups[0], want) {
+			t.Errorf("round trip = %+v, want %+v", groups[0], want)
+		}
+	})
+}
+
+func TestMACHostGroupClientEscaping(t *testing.T) {
+	ctx := context.Background()
+	client := NewClient(xmlapitest.NewEchoClient(t))
+
+	for _, name := range xmlapitest.HostileNames {
+		t.Run(name, func(t *testing.T) {
+			want := &MACHostGroup{
+				Name:        name,
+				Description: "desc " + name,
+				MACHostList: &MACHostList{MACHosts: []string{name}},
+			}
+			if err := client.CreateMACHostGroup(ctx, want); err != nil {
+				t.Fatalf("create: %v", err)
+			}
+			got, err := client.ReadMACHostGroup(ctx, name)
+			if err != nil || got == nil {
+				t.Fatalf("read: %v, %v", got, err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("round trip mismatch:\n got  %#v\n want %#v", got, want)
+			}
+
+			want.Description = "updated " + name
+			if err := client.UpdateMACHostGroup(ctx, want); err != nil {
+				t.Fatalf("update: %v", err)
+			}
+			if got, _ := client.ReadMACHostGroup(ctx, name); got == nil || got.Description != want.Description {
+				t.Errorf("updated description = %#v, want %q", got, want.Description)
+			}
+
+			if err := client.DeleteMACHostGroup(ctx, name); err != nil {
+				t.Fatalf("delete: %v", err)
+			}
+			if got, err := client.ReadMACHostGroup(ctx, name); got != nil || err != nil {
+				t.Errorf("read after delete = %#v, %v", got, err)
+			}
+		})
+	}
+}
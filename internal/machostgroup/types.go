@@ -0,0 +1,23 @@
+package machostgroup
+
+// MACHostGroup represents the firewall MAC host group model
+type MACHostGroup struct {
+	Name          string       `xml:"Name"`
+	Description   string       `xml:"Description"`
+	MACHostList   *MACHostList `xml:"MACHostList"`
+	TransactionID string       `xml:"transactionid,attr"`
+}
+
+// MACHostList represents the MAC hosts of a group
+type MACHostList struct {
+	MACHosts []string `xml:"MACHost"`
+}
+
+// EntityTypeMACHostGroup is the XML API tag of MAC host group objects
+const EntityTypeMACHostGroup = "MACHostGroup"
+
+// EntityType returns the XML API tag of the MAC host group
+func (g MACHostGroup) EntityType() string { return EntityTypeMACHostGroup }
+
+// EntityName returns the name of the MAC host group
+func (g MACHostGroup) EntityName() string { return g.Name }
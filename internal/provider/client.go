@@ -16,9 +16,12 @@ type SophosClient struct {
 }
 
 // NewSophosClient creates a new API client
-func NewSophosClient(endpoint, username, password string, insecure bool) *SophosClient {
+func NewSophosClient(config common.Config) (*SophosClient, error) {
 	// Create the base client
-	baseClient := common.NewBaseClient(endpoint, username, password, insecure)
+	baseClient, err := common.NewBaseClient(config)
+	if err != nil {
+		return nil, err
+	}
 	
 	// Create the main client
 	client := &SophosClient{
@@ -30,5 +33,5 @@ func NewSophosClient(endpoint, username, password string, insecure bool) *Sophos
 	client.MACHost = machost.NewClient(baseClient)
 	client.FirewallRule = firewallrule.NewClient(baseClient)
 	
-	return client
+	return client, nil
 }
\ No newline at end of file
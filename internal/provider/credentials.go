@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Environment variables used when the provider block leaves the connection
+// attributes unset
+const (
+	envEndpoint = "SOPHOS_ENDPOINT"
+	envUsername = "SOPHOS_USERNAME"
+	envPassword = "SOPHOS_PASSWORD"
+)
+
+// stringOrEnv returns the attribute value, or the environment variable when
+// the attribute is not set. Missing values are reported as errors.
+func stringOrEnv(diags *diag.Diagnostics, attribute string, value types.String, env string) string {
+	if value.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Unknown Provider Attribute",
+			"The "+attribute+" is not known until apply. Set it to a static value or use the "+env+" environment variable.",
+		)
+		return ""
+	}
+	if !value.IsNull() && value.ValueString() != "" {
+		return value.ValueString()
+	}
+	if fromEnv := os.Getenv(env); fromEnv != "" {
+		return fromEnv
+	}
+	diags.AddAttributeError(
+		path.Root(attribute),
+		"Missing Provider Attribute",
+		"Set the "+attribute+" in the provider block or use the "+env+" environment variable.",
+	)
+	return ""
+}
+
+// resolvePassword returns the password from the first configured source:
+// password, password_file, password_command or the SOPHOS_PASSWORD
+// environment variable. At most one of the attributes may be set.
+func resolvePassword(ctx context.Context, diags *diag.Diagnostics, config SophosProviderModel) string {
+	var sources []string
+	if !config.Password.IsNull() {
+		sources = append(sources, "password")
+	}
+	if !config.PasswordFile.IsNull() {
+		sources = append(sources, "password_file")
+	}
+	if !config.PasswordCommand.IsNull() {
+		sources = append(sources, "password_command")
+	}
+	if len(sources) > 1 {
+		diags.AddError(
+			"Conflicting Password Sources",
+			"Only one of password, password_file and password_command may be set, got "+strings.Join(sources, ", ")+".",
+		)
+		return ""
+	}
+
+	switch {
+	case !config.PasswordFile.IsNull():
+		if config.PasswordFile.IsUnknown() {
+			break
+		}
+		data, err := os.ReadFile(config.PasswordFile.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("password_file"), "Unable to Read Password File", err.Error())
+			return ""
+		}
+		return strings.TrimRight(string(data), "\r\n")
+
+	case !config.PasswordCommand.IsNull():
+		if config.PasswordCommand.IsUnknown() {
+			break
+		}
+		var args []string
+		diags.Append(config.PasswordCommand.ElementsAs(ctx, &args, false)...)
+		if diags.HasError() {
+			return ""
+		}
+		password, err := runPasswordCommand(ctx, args)
+		if err != nil {
+			diags.AddAttributeError(path.Root("password_command"), "Password Command Failed", err.Error())
+			return ""
+		}
+		return password
+
+	default:
+		return stringOrEnv(diags, "password", config.Password, envPassword)
+	}
+
+	diags.AddError(
+		"Unknown Provider Attribute",
+		"The password source is not known until apply. Set it to a static value or use the "+envPassword+" environment variable.",
+	)
+	return ""
+}
+
+// runPasswordCommand runs the helper without a shell and returns its output
+// without the trailing newline. The password stays in memory.
+func runPasswordCommand(ctx context.Context, args []string) (string, error) {
+	if len(args) == 0 || args[0] == "" {
+		return "", errors.New("password_command must name the program to run")
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("%s: %w: %s", args[0], err, message)
+		}
+		return "", fmt.Errorf("%s: %w", args[0], err)
+	}
+
+	password := strings.TrimRight(stdout.String(), "\r\n")
+	if password == "" {
+		return "", fmt.Errorf("%s printed an empty password", args[0])
+	}
+	return password, nil
+}
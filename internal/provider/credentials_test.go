@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func passwordConfig() SophosProviderModel {
+	return SophosProviderModel{
+		Password:        types.StringNull(),
+		PasswordFile:    types.StringNull(),
+		PasswordCommand: types.ListNull(types.StringType),
+	}
+}
+
+func TestResolvePasswordFromFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(file, []byte("s3cr3t\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	config := passwordConfig()
+	config.PasswordFile = types.StringValue(file)
+
+	var diags diag.Diagnostics
+	if got := resolvePassword(context.Background(), &diags, config); got != "s3cr3t" || diags.HasError() {
+		t.Errorf("password = %q, diagnostics %v", got, diags)
+	}
+}
+
+func TestResolvePasswordFromCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses echo")
+	}
+	config := passwordConfig()
+	config.PasswordCommand = types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("echo"), types.StringValue("from helper"),
+	})
+
+	var diags diag.Diagnostics
+	if got := resolvePassword(context.Background(), &diags, config); got != "from helper" || diags.HasError() {
+		t.Errorf("password = %q, diagnostics %v", got, diags)
+	}
+}
+
+func TestResolvePasswordFromEnv(t *testing.T) {
+	t.Setenv(envPassword, "from env")
+
+	var diags diag.Diagnostics
+	if got := resolvePassword(context.Background(), &diags, passwordConfig()); got != "from env" || diags.HasError() {
+		t.Errorf("password = %q, diagnostics %v", got, diags)
+	}
+}
+
+func TestResolvePasswordConflicts(t *testing.T) {
+	config := passwordConfig()
+	config.Password = types.StringValue("inline")
+	config.PasswordFile = types.StringValue("/dev/null")
+
+	var diags diag.Diagnostics
+	resolvePassword(context.Background(), &diags, config)
+	if !diags.HasError() {
+		t.Error("expected an error for two password sources")
+	}
+}
+
+func TestResolvePasswordMissing(t *testing.T) {
+	t.Setenv(envPassword, "")
+
+	var diags diag.Diagnostics
+	resolvePassword(context.Background(), &diags, passwordConfig())
+	if !diags.HasError() {
+		t.Error("expected an error without any password source")
+	}
+}
@@ -3,12 +3,12 @@ package provider
 import (
 	"context"
 	"fmt"
-	"log"
-	"github.com/hashicorp/terraform-plugin-framework/datasource"  // Required for datasource functions
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/types" 
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/firewallrule"
-	// Required for types.String and other type functions
 )
 
 // Ensure the implementation satisfies the expected interfaces
@@ -31,202 +31,231 @@ func (d *firewallRuleDataSource) Metadata(_ context.Context, req datasource.Meta
 
 // Schema defines the schema for the data source
 func (d *firewallRuleDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := firewallRuleDataSourceAttributes()
+	attributes["name"] = schema.StringAttribute{
+		Description: "Name of the firewall rule",
+		Required:    true,
+	}
 	resp.Schema = schema.Schema{
 		Description: "Fetches a Sophos Firewall rule by name.",
-		Attributes: map[string]schema.Attribute{
-			"name": schema.StringAttribute{
-				Description: "Name of the firewall rule",
-				Required:    true,
-			},
-			"description": schema.StringAttribute{
-				Description: "Description of the rule",
-				Optional:    true,
-			},
-			"ip_family": schema.StringAttribute{
-				Description: "IP Family (IPv4 or IPv6)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"status": schema.StringAttribute{
-				Description: "Status (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"position": schema.StringAttribute{
-				Description: "Position (Top, Bottom, After, Before)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"policy_type": schema.StringAttribute{
-				Description: "Policy Type (Network)",
-				Required:    true,
-			},
-			"after_rule": schema.StringAttribute{
-				Description: "Rule to position after (used when position is 'After')",
-				Optional:    true,
-			},
-			"before_rule": schema.StringAttribute{
-				Description: "Rule to position before (used when position is 'Before')",
-				Optional:    true,
-			},
-			"action": schema.StringAttribute{
-				Description: "Action (Accept, Reject, Drop)",
-				Required:    true,
-			},
-			"log_traffic": schema.StringAttribute{
-				Description: "Log traffic (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"skip_local_destined": schema.StringAttribute{
-				Description: "Skip local destined (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"source_zones": schema.ListAttribute{
-				Description: "List of source zones",
-				Required:    true,
-				ElementType: types.StringType,
-			},
-			"destination_zones": schema.ListAttribute{
-				Description: "List of destination zones",
-				Required:    true,
-				ElementType: types.StringType,
-			},
-			"schedule": schema.StringAttribute{
-				Description: "Schedule name",
-				Optional:    true,
-				Computed:    true,
-			},
-			"source_networks": schema.ListAttribute{
-				Description: "List of source networks",
-				Optional:    true,
-				ElementType: types.StringType,
-			},
-			"destination_networks": schema.ListAttribute{
-				Description: "List of destination networks",
-				Optional:    true,
-				ElementType: types.StringType,
-			},
-			"dscp_marking": schema.StringAttribute{
-				Description: "DSCP Marking value",
-				Optional:    true,
-				Computed:    true,
-			},
-			"web_filter": schema.StringAttribute{
-				Description: "Web Filter policy",
-				Optional:    true,
-				Computed:    true,
-			},
-			"web_category_base_qos_policy": schema.StringAttribute{
-				Description: "Web Category Base QoS Policy",
-				Optional:    true,
-				Computed:    true,
-			},
-			"block_quick_quic": schema.StringAttribute{
-				Description: "Block Quick/QUIC protocol (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"scan_virus": schema.StringAttribute{
-				Description: "Scan for viruses (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"zero_day_protection": schema.StringAttribute{
-				Description: "Zero Day Protection (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"proxy_mode": schema.StringAttribute{
-				Description: "Proxy Mode (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"decrypt_https": schema.StringAttribute{
-				Description: "Decrypt HTTPS (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"application_control": schema.StringAttribute{
-				Description: "Application Control policy",
-				Optional:    true,
-				Computed:    true,
-			},
-			"application_base_qos_policy": schema.StringAttribute{
-				Description: "Application Base QoS Policy",
-				Optional:    true,
-				Computed:    true,
-			},
-			"intrusion_prevention": schema.StringAttribute{
-				Description: "Intrusion Prevention policy",
-				Optional:    true,
-				Computed:    true,
-			},
-			"traffic_shapping_policy": schema.StringAttribute{
-				Description: "Traffic Shaping Policy",
-				Optional:    true,
-				Computed:    true,
-			},
-			"scan_smtp": schema.StringAttribute{
-				Description: "Scan SMTP (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"scan_smtps": schema.StringAttribute{
-				Description: "Scan SMTPS (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"scan_imap": schema.StringAttribute{
-				Description: "Scan IMAP (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"scan_imaps": schema.StringAttribute{
-				Description: "Scan IMAPS (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"scan_pop3": schema.StringAttribute{
-				Description: "Scan POP3 (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"scan_pop3s": schema.StringAttribute{
-				Description: "Scan POP3S (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"scan_ftp": schema.StringAttribute{
-				Description: "Scan FTP (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"source_security_heartbeat": schema.StringAttribute{
-				Description: "Source Security Heartbeat (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"minimum_source_hb_permitted": schema.StringAttribute{
-				Description: "Minimum Source HB Permitted",
-				Optional:    true,
-				Computed:    true,
-			},
-			"dest_security_heartbeat": schema.StringAttribute{
-				Description: "Destination Security Heartbeat (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"minimum_destination_hb_permitted": schema.StringAttribute{
-				Description: "Minimum Destination HB Permitted",
-				Optional:    true,
-				Computed:    true,
-			},
+		Attributes:  attributes,
+	}
+}
+
+// firewallRuleDataSourceAttributes returns the computed attributes of a
+// firewall rule as read from the firewall, shared with the
+// sophosfirewall_firewall_rules data source
+func firewallRuleDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			Description: "Name of the firewall rule",
+			Computed:    true,
+		},
+		"description": schema.StringAttribute{
+			Description: "Description of the rule",
+			Computed:    true,
+		},
+		"ip_family": schema.StringAttribute{
+			Description: "IP Family (IPv4 or IPv6)",
+			Computed:    true,
+		},
+		"enabled": schema.BoolAttribute{
+			Description: "Whether the rule is enabled",
+			Computed:    true,
+		},
+		"position": schema.StringAttribute{
+			Description: "Position (Top, Bottom, After, Before)",
+			Computed:    true,
+		},
+		"policy_type": schema.StringAttribute{
+			Description: "Policy Type (Network or User)",
+			Computed:    true,
+		},
+		"after_rule": schema.StringAttribute{
+			Description: "Rule to position after (used when position is 'After')",
+			Computed:    true,
+		},
+		"before_rule": schema.StringAttribute{
+			Description: "Rule to position before (used when position is 'Before')",
+			Computed:    true,
+		},
+		"network_policy": schema.SingleNestedAttribute{
+			Description: "Network policy of a rule with policy_type Network",
+			Computed:    true,
+			Attributes:  policyDataSourceAttributes(),
+		},
+		"user_policy": schema.SingleNestedAttribute{
+			Description: "User policy of a rule with policy_type User",
+			Computed:    true,
+			Attributes:  userPolicyDataSourceAttributes(),
 		},
 	}
 }
 
+// policyDataSourceAttributes returns the computed attributes of a network
+// policy, which user policies have as well
+func policyDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"action": schema.StringAttribute{
+			Description: "Action (Accept, Reject, Drop)",
+			Computed:    true,
+		},
+		"log_traffic": schema.BoolAttribute{
+			Description: "Log traffic",
+			Computed:    true,
+		},
+		"skip_local_destined": schema.BoolAttribute{
+			Description: "Skip local destined",
+			Computed:    true,
+		},
+		"schedule": schema.StringAttribute{
+			Description: "Schedule name",
+			Computed:    true,
+		},
+		"source_zones": schema.SetAttribute{
+			Description: "Set of source zones",
+			Computed:    true,
+			ElementType: types.StringType,
+		},
+		"destination_zones": schema.SetAttribute{
+			Description: "Set of destination zones",
+			Computed:    true,
+			ElementType: types.StringType,
+		},
+		"source_networks": schema.SetAttribute{
+			Description: "Set of source networks",
+			Computed:    true,
+			ElementType: types.StringType,
+		},
+		"destination_networks": schema.SetAttribute{
+			Description: "Set of destination networks",
+			Computed:    true,
+			ElementType: types.StringType,
+		},
+		"services": schema.SetAttribute{
+			Description: "Set of services",
+			Computed:    true,
+			ElementType: types.StringType,
+		},
+		"dscp_marking": schema.StringAttribute{
+			Description: "DSCP Marking value",
+			Computed:    true,
+		},
+		"web_filter": schema.StringAttribute{
+			Description: "Web Filter policy",
+			Computed:    true,
+		},
+		"web_category_base_qos_policy": schema.StringAttribute{
+			Description: "Web Category Base QoS Policy",
+			Computed:    true,
+		},
+		"block_quick_quic": schema.BoolAttribute{
+			Description: "Block Quick/QUIC protocol",
+			Computed:    true,
+		},
+		"scan_virus": schema.BoolAttribute{
+			Description: "Scan for viruses",
+			Computed:    true,
+		},
+		"zero_day_protection": schema.BoolAttribute{
+			Description: "Zero Day Protection",
+			Computed:    true,
+		},
+		"proxy_mode": schema.BoolAttribute{
+			Description: "Proxy Mode",
+			Computed:    true,
+		},
+		"decrypt_https": schema.BoolAttribute{
+			Description: "Decrypt HTTPS",
+			Computed:    true,
+		},
+		"application_control": schema.StringAttribute{
+			Description: "Application Control policy",
+			Computed:    true,
+		},
+		"application_base_qos_policy": schema.StringAttribute{
+			Description: "Application Base QoS Policy",
+			Computed:    true,
+		},
+		"intrusion_prevention": schema.StringAttribute{
+			Description: "Intrusion Prevention policy",
+			Computed:    true,
+		},
+		"traffic_shaping_policy": schema.StringAttribute{
+			Description: "Traffic Shaping Policy",
+			Computed:    true,
+		},
+		"scan_smtp": schema.BoolAttribute{
+			Description: "Scan SMTP",
+			Computed:    true,
+		},
+		"scan_smtps": schema.BoolAttribute{
+			Description: "Scan SMTPS",
+			Computed:    true,
+		},
+		"scan_imap": schema.BoolAttribute{
+			Description: "Scan IMAP",
+			Computed:    true,
+		},
+		"scan_imaps": schema.BoolAttribute{
+			Description: "Scan IMAPS",
+			Computed:    true,
+		},
+		"scan_pop3": schema.BoolAttribute{
+			Description: "Scan POP3",
+			Computed:    true,
+		},
+		"scan_pop3s": schema.BoolAttribute{
+			Description: "Scan POP3S",
+			Computed:    true,
+		},
+		"scan_ftp": schema.BoolAttribute{
+			Description: "Scan FTP",
+			Computed:    true,
+		},
+		"source_security_heartbeat": schema.BoolAttribute{
+			Description: "Source Security Heartbeat",
+			Computed:    true,
+		},
+		"minimum_source_hb_permitted": schema.StringAttribute{
+			Description: "Minimum Source HB Permitted",
+			Computed:    true,
+		},
+		"dest_security_heartbeat": schema.BoolAttribute{
+			Description: "Destination Security Heartbeat",
+			Computed:    true,
+		},
+		"minimum_destination_hb_permitted": schema.StringAttribute{
+			Description: "Minimum Destination HB Permitted",
+			Computed:    true,
+		},
+	}
+}
+
+// userPolicyDataSourceAttributes returns the computed attributes of a user
+// policy
+func userPolicyDataSourceAttributes() map[string]schema.Attribute {
+	attributes := policyDataSourceAttributes()
+	attributes["identity"] = schema.SetAttribute{
+		Description: "Set of users and groups the rule matches",
+		Computed:    true,
+		ElementType: types.StringType,
+	}
+	attributes["match_identity"] = schema.BoolAttribute{
+		Description: "Match known users only",
+		Computed:    true,
+	}
+	attributes["show_captive_portal"] = schema.BoolAttribute{
+		Description: "Show the captive portal to unknown users",
+		Computed:    true,
+	}
+	attributes["data_accounting"] = schema.BoolAttribute{
+		Description: "Account the traffic of the users against their data quota",
+		Computed:    true,
+	}
+	return attributes
+}
+
 // Configure adds the provider configured client to the data source
 func (d *firewallRuleDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -248,7 +277,7 @@ func (d *firewallRuleDataSource) Configure(_ context.Context, req datasource.Con
 
 // Read refreshes the Terraform state with the latest data
 func (d *firewallRuleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var state firewallRuleResourceModel
+	var state firewallRuleModel
 
 	// Get current state
 	diags := req.Config.Get(ctx, &state)
@@ -259,24 +288,15 @@ func (d *firewallRuleDataSource) Read(ctx context.Context, req datasource.ReadRe
 
 	// Get the name of the rule to fetch
 	ruleName := state.Name.ValueString()
-	if ruleName == "" {
-		resp.Diagnostics.AddError(
-			"Missing required parameter",
-			"The 'name' attribute is required to fetch a firewall rule",
-		)
-		return
-	}
 
-	
 	// Call the API to get the firewall rule
-	rule, err := d.client.ReadFirewallRule(ruleName)
+	rule, err := d.client.ReadFirewallRule(ctx, ruleName)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading firewall rule", err.Error())
+		addClientError(&resp.Diagnostics, "Error reading firewall rule", err)
 		return
 	}
 
-	log.Printf("[DEBUG] Data Source Read function FW RULE 30-500- Retrieved FW rule: %+v", ruleName) // Log the retrieved object
-
+	tflog.Debug(ctx, "Retrieved firewall rule", map[string]interface{}{"name": ruleName})
 
 	if rule == nil {
 		resp.Diagnostics.AddError(
@@ -287,109 +307,9 @@ func (d *firewallRuleDataSource) Read(ctx context.Context, req datasource.ReadRe
 	}
 
 	// Map the API response to the data source schema
-	state = mapFirewallRuleToModel(rule)
+	state = apiToModelFirewallRule(*rule)
 
 	// Set state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
-
-// mapFirewallRuleToModel converts an API firewall rule to the resource model
-func mapFirewallRuleToModel(rule *firewallrule.FirewallRule) firewallRuleResourceModel {
-	model := firewallRuleResourceModel{
-		Name:        types.StringValue(rule.Name),
-		PolicyType:  types.StringValue(rule.PolicyType),
-		IPFamily:    types.StringValue(rule.IPFamily),
-		Status:      types.StringValue(rule.Status),
-		Position:    types.StringValue(rule.Position),
-	}
-
-	// Handle optional string fields
-	if rule.Description != "" {
-		model.Description = types.StringValue(rule.Description)
-	} else {
-		model.Description = types.StringNull()
-	}
-
-	// Handle After/Before rule if specified
-	if rule.After != nil && rule.After.Name != "" {
-		model.AfterRule = types.StringValue(rule.After.Name)
-	} else {
-		model.AfterRule = types.StringNull()
-	}
-
-	if rule.Before != nil && rule.Before.Name != "" {
-		model.BeforeRule = types.StringValue(rule.Before.Name)
-	} else {
-		model.BeforeRule = types.StringNull()
-	}
-
-	// Network Policy settings
-	if rule.NetworkPolicy != nil {
-		model.Action = types.StringValue(rule.NetworkPolicy.Action)
-		model.LogTraffic = types.StringValue(rule.NetworkPolicy.LogTraffic)
-		model.SkipLocalDestined = types.StringValue(rule.NetworkPolicy.SkipLocalDestined)
-		model.Schedule = types.StringValue(rule.NetworkPolicy.Schedule)
-		model.DSCPMarking = types.StringValue(rule.NetworkPolicy.DSCPMarking)
-		model.WebFilter = types.StringValue(rule.NetworkPolicy.WebFilter)
-		model.WebCategoryBaseQoSPolicy = types.StringValue(rule.NetworkPolicy.WebCategoryBaseQoSPolicy)
-		model.BlockQuickQuic = types.StringValue(rule.NetworkPolicy.BlockQuickQuic)
-		model.ScanVirus = types.StringValue(rule.NetworkPolicy.ScanVirus)
-		model.ZeroDayProtection = types.StringValue(rule.NetworkPolicy.ZeroDayProtection)
-		model.ProxyMode = types.StringValue(rule.NetworkPolicy.ProxyMode)
-		model.DecryptHTTPS = types.StringValue(rule.NetworkPolicy.DecryptHTTPS)
-		model.ApplicationControl = types.StringValue(rule.NetworkPolicy.ApplicationControl)
-		model.ApplicationBaseQoSPolicy = types.StringValue(rule.NetworkPolicy.ApplicationBaseQoSPolicy)
-		model.IntrusionPrevention = types.StringValue(rule.NetworkPolicy.IntrusionPrevention)
-		model.TrafficShappingPolicy = types.StringValue(rule.NetworkPolicy.TrafficShappingPolicy)
-		model.ScanSMTP = types.StringValue(rule.NetworkPolicy.ScanSMTP)
-		model.ScanSMTPS = types.StringValue(rule.NetworkPolicy.ScanSMTPS)
-		model.ScanIMAP = types.StringValue(rule.NetworkPolicy.ScanIMAP)
-		model.ScanIMAPS = types.StringValue(rule.NetworkPolicy.ScanIMAPS)
-		model.ScanPOP3 = types.StringValue(rule.NetworkPolicy.ScanPOP3)
-		model.ScanPOP3S = types.StringValue(rule.NetworkPolicy.ScanPOP3S)
-		model.ScanFTP = types.StringValue(rule.NetworkPolicy.ScanFTP)
-		model.SourceSecurityHeartbeat = types.StringValue(rule.NetworkPolicy.SourceSecurityHeartbeat)
-		model.MinimumSourceHBPermitted = types.StringValue(rule.NetworkPolicy.MinimumSourceHBPermitted)
-		model.DestSecurityHeartbeat = types.StringValue(rule.NetworkPolicy.DestSecurityHeartbeat)
-		model.MinimumDestinationHBPermitted = types.StringValue(rule.NetworkPolicy.MinimumDestinationHBPermitted)
-
-		// Handle source zones
-		if rule.NetworkPolicy.SourceZones != nil && len(rule.NetworkPolicy.SourceZones.Zones) > 0 {
-			sourceZones := make([]types.String, len(rule.NetworkPolicy.SourceZones.Zones))
-			for i, zone := range rule.NetworkPolicy.SourceZones.Zones {
-				sourceZones[i] = types.StringValue(zone)
-			}
-			model.SourceZones = sourceZones
-		}
-
-		// Handle destination zones
-		if rule.NetworkPolicy.DestinationZones != nil && len(rule.NetworkPolicy.DestinationZones.Zones) > 0 {
-			destZones := make([]types.String, len(rule.NetworkPolicy.DestinationZones.Zones))
-			for i, zone := range rule.NetworkPolicy.DestinationZones.Zones {
-				destZones[i] = types.StringValue(zone)
-			}
-			model.DestinationZones = destZones
-		}
-
-		// Handle source networks
-		if rule.NetworkPolicy.SourceNetworks != nil && len(rule.NetworkPolicy.SourceNetworks.Networks) > 0 {
-			sourceNetworks := make([]types.String, len(rule.NetworkPolicy.SourceNetworks.Networks))
-			for i, network := range rule.NetworkPolicy.SourceNetworks.Networks {
-				sourceNetworks[i] = types.StringValue(network)
-			}
-			model.SourceNetworks = sourceNetworks
-		}
-
-		// Handle destination networks
-		if rule.NetworkPolicy.DestinationNetworks != nil && len(rule.NetworkPolicy.DestinationNetworks.Networks) > 0 {
-			destNetworks := make([]types.String, len(rule.NetworkPolicy.DestinationNetworks.Networks))
-			for i, network := range rule.NetworkPolicy.DestinationNetworks.Networks {
-				destNetworks[i] = types.StringValue(network)
-			}
-			model.DestinationNetworks = destNetworks
-		}
-	}
-
-	return model
-}
\ No newline at end of file
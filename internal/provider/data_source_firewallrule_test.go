@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFirewallRuleDataSource(t *testing.T) {
+	server, providerConfig := testAccServer(t)
+	for _, entity := range []string{
+		`<IPHost><Name>web</Name><IPFamily>IPv4</IPFamily><HostType>IP</HostType><IPAddress>10.0.0.1</IPAddress></IPHost>`,
+		`<FirewallRule><Name>allow-web</Name><Description>Allow web traffic</Description><IPFamily>IPv4</IPFamily>` +
+			`<Status>Enable</Status><Position>Top</Position><PolicyType>Network</PolicyType>` +
+			`<NetworkPolicy><Action>Accept</Action><LogTraffic>Disable</LogTraffic><SkipLocalDestined>Disable</SkipLocalDestined>` +
+			`<Schedule>All The Time</Schedule><SourceZones><Zone>LAN</Zone></SourceZones><DestinationZones><Zone>DMZ</Zone></DestinationZones>` +
+			`<DestinationNetworks><Network>web</Network></DestinationNetworks></NetworkPolicy></FirewallRule>`,
+	} {
+		if err := server.Put(entity); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "sophosfirewall_firewallrule" "test" {
+  name = "allow-web"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sophosfirewall_firewallrule.test", "description", "Allow web traffic"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_firewallrule.test", "policy_type", "Network"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_firewallrule.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_firewallrule.test", "network_policy.action", "Accept"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_firewallrule.test", "network_policy.schedule", "All The Time"),
+					resource.TestCheckTypeSetElemAttr("data.sophosfirewall_firewallrule.test", "network_policy.source_zones.*", "LAN"),
+					resource.TestCheckTypeSetElemAttr("data.sophosfirewall_firewallrule.test", "network_policy.destination_zones.*", "DMZ"),
+					resource.TestCheckTypeSetElemAttr("data.sophosfirewall_firewallrule.test", "network_policy.destination_networks.*", "web"),
+					resource.TestCheckNoResourceAttr("data.sophosfirewall_firewallrule.test", "after_rule"),
+				),
+			},
+			{
+				Config: providerConfig + `
+data "sophosfirewall_firewallrule" "test" {
+  name = "missing"
+}
+`,
+				ExpectError: regexp.MustCompile(`Firewall rule not found`),
+			},
+		},
+	})
+}
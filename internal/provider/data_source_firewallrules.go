@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/firewallrule"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var _ datasource.DataSource = &firewallRulesDataSource{}
+
+// firewallRulesDataSource is the data source implementation
+type firewallRulesDataSource struct {
+	client *firewallrule.Client
+}
+
+// firewallRulesDataSourceModel maps the data source schema data
+type firewallRulesDataSourceModel struct {
+	NameRegex     types.String        `tfsdk:"name_regex"`
+	IPFamily      types.String        `tfsdk:"ip_family"`
+	Zone          types.String        `tfsdk:"zone"`
+	Network       types.String        `tfsdk:"network"`
+	Action        types.String        `tfsdk:"action"`
+	Enabled       types.Bool          `tfsdk:"enabled"`
+	Names         []types.String      `tfsdk:"names"`
+	FirewallRules []firewallRuleModel `tfsdk:"firewall_rules"`
+}
+
+// NewFirewallRulesDataSource creates a new data source
+func NewFirewallRulesDataSource() datasource.DataSource {
+	return &firewallRulesDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *firewallRulesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_firewall_rules"
+}
+
+// Schema defines the schema for the data source
+func (d *firewallRulesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches the Sophos Firewall rules matching the filters",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": nameRegexAttribute,
+			"ip_family":  filterAttribute("IP Family the rules must have (IPv4 or IPv6)"),
+			"zone":       filterAttribute("Name of a zone the rules must use as source or destination zone"),
+			"network":    filterAttribute("Name of a host or group the rules must use as source or destination network"),
+			"action":     filterAttribute("Action the rules must have (Accept, Reject, Drop)"),
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the rules must be enabled or disabled",
+				Optional:    true,
+			},
+			"names": schema.ListAttribute{
+				Description: "Names of the matching rules in rule order",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"firewall_rules": schema.ListNestedAttribute{
+				Description: "Matching rules in rule order",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: firewallRuleDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *firewallRulesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*SophosClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *SophosClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = firewallrule.NewClient(client.BaseClient)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *firewallRulesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config firewallRulesDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex := compileNameRegex(config.NameRegex, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules, err := d.client.ListFirewallRules(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error listing Firewall Rules", err)
+		return
+	}
+
+	tflog.Debug(ctx, "Retrieved Firewall Rules", map[string]interface{}{"count": len(rules)})
+
+	// Keep the rule order of the firewall
+	config.Names = []types.String{}
+	config.FirewallRules = []firewallRuleModel{}
+	for _, rule := range rules {
+		model := apiToModelFirewallRule(rule)
+		policy := model.policy()
+		if !matchName(nameRegex, rule.Name) ||
+			!matchFilter(config.IPFamily, rule.IPFamily) ||
+			!matchFilter(config.Action, policy.Action.ValueString()) ||
+			!matchBool(config.Enabled, model.Enabled) ||
+			!matchMember(config.Zone, policy.SourceZones, policy.DestinationZones) ||
+			!matchMember(config.Network, policy.SourceNetworks, policy.DestinationNetworks) {
+			continue
+		}
+		config.Names = append(config.Names, types.StringValue(rule.Name))
+		config.FirewallRules = append(config.FirewallRules, model)
+	}
+
+	// Set the state
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFirewallRulesDataSource(t *testing.T) {
+	server, providerConfig := testAccServer(t)
+	for _, entity := range []string{
+		`<IPHost><Name>web</Name><IPFamily>IPv4</IPFamily><HostType>IP</HostType><IPAddress>10.0.0.1</IPAddress></IPHost>`,
+		`<FirewallRule><Name>z-allow-web</Name><IPFamily>IPv4</IPFamily><Status>Enable</Status><Position>Top</Position><PolicyType>Network</PolicyType>` +
+			`<NetworkPolicy><Action>Accept</Action><SourceZones><Zone>LAN</Zone></SourceZones><DestinationZones><Zone>DMZ</Zone></DestinationZones>` +
+			`<DestinationNetworks><Network>web</Network></DestinationNetworks></NetworkPolicy></FirewallRule>`,
+		`<FirewallRule><Name>a-drop-wan</Name><IPFamily>IPv4</IPFamily><Status>Disable</Status><Position>Bottom</Position><PolicyType>Network</PolicyType>` +
+			`<NetworkPolicy><Action>Drop</Action><SourceZones><Zone>WAN</Zone></SourceZones></NetworkPolicy></FirewallRule>`,
+	} {
+		if err := server.Put(entity); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "sophosfirewall_firewall_rules" "all" {}
+
+data "sophosfirewall_firewall_rules" "dmz" {
+  zone    = "DMZ"
+  network = "web"
+  action  = "accept"
+}
+
+data "sophosfirewall_firewall_rules" "disabled" {
+  enabled = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Rule order, not name order
+					resource.TestCheckResourceAttr("data.sophosfirewall_firewall_rules.all", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_firewall_rules.all", "names.0", "z-allow-web"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_firewall_rules.all", "names.1", "a-drop-wan"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_firewall_rules.dmz", "names.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.sophosfirewall_firewall_rules.dmz", "firewall_rules.0.network_policy.source_zones.*", "LAN"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_firewall_rules.disabled", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_firewall_rules.disabled", "firewall_rules.0.network_policy.action", "Drop"),
+				),
+			},
+		},
+	})
+}
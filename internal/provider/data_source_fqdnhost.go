@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/fqdnhost"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var _ datasource.DataSource = &fqdnHostDataSource{}
+
+// fqdnHostDataSource is the data source implementation
+type fqdnHostDataSource struct {
+	client *fqdnhost.Client
+}
+
+// NewFQDNHostDataSource creates a new data source
+func NewFQDNHostDataSource() datasource.DataSource {
+	return &fqdnHostDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *fqdnHostDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_fqdnhost"
+}
+
+// Schema defines the schema for the data source
+func (d *fqdnHostDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches a Sophos Firewall FQDN Host object",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "Name of the FQDN Host",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of the FQDN Host",
+				Computed:    true,
+			},
+			"fqdn": schema.StringAttribute{
+				Description: "Fully qualified domain name, a leading *. matches every subdomain",
+				Computed:    true,
+			},
+			"host_groups": schema.ListAttribute{
+				Description: "List of FQDN Host Groups this FQDN Host belongs to",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *fqdnHostDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*SophosClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *SophosClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = fqdnhost.NewClient(client.BaseClient)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *fqdnHostDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config fqdnHostModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	host, err := d.client.ReadFQDNHost(ctx, config.Name.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error reading FQDN Host", err)
+		return
+	}
+
+	tflog.Debug(ctx, "Retrieved FQDN Host", map[string]interface{}{"object": fmt.Sprintf("%+v", host)})
+
+	if host == nil {
+		resp.Diagnostics.AddError(
+			"FQDN Host not found",
+			fmt.Sprintf("FQDN Host with name %s not found", config.Name.ValueString()),
+		)
+		return
+	}
+
+	config = apiToModelFQDNHost(*host)
+
+	// Set the state
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFQDNHostDataSource(t *testing.T) {
+	server, providerConfig := testAccServer(t)
+	for _, entity := range []string{
+		`<FQDNHost><Name>office</Name><Description>Microsoft 365</Description><FQDN>*.office.com</FQDN></FQDNHost>`,
+		`<FQDNHostGroup><Name>saas</Name><FQDNHostList><FQDNHost>office</FQDNHost></FQDNHostList></FQDNHostGroup>`,
+	} {
+		if err := server.Put(entity); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "sophosfirewall_fqdnhost" "test" {
+  name = "office"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sophosfirewall_fqdnhost.test", "description", "Microsoft 365"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_fqdnhost.test", "fqdn", "*.office.com"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_fqdnhost.test", "host_groups.0", "saas"),
+				),
+			},
+			{
+				Config: providerConfig + `
+data "sophosfirewall_fqdnhost" "test" {
+  name = "missing"
+}
+`,
+				ExpectError: regexp.MustCompile(`FQDN Host not found`),
+			},
+		},
+	})
+}
@@ -0,0 +1,16 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// NewFQDNHostGroupDataSource creates a new data source
+func NewFQDNHostGroupDataSource() datasource.DataSource {
+	return newGroupDataSource(fqdnHostGroupType, schema.ListAttribute{
+		Description: "List of FQDN Hosts this FQDN Host Group refers to",
+		Computed:    true,
+		ElementType: types.StringType,
+	})
+}
@@ -0,0 +1,8 @@
+package provider
+
+import "testing"
+
+func TestAccFQDNHostGroupDataSource(t *testing.T) {
+	testAccGroupDataSource(t, fqdnHostGroupTest,
+		`<FQDNHostGroup><Name>saas</Name><Description>SaaS applications</Description><FQDNHostList><FQDNHost>office</FQDNHost><FQDNHost>github</FQDNHost></FQDNHostList></FQDNHostGroup>`)
+}
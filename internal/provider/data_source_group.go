@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var _ datasource.DataSource = &groupDataSource[struct{}]{}
+
+// groupDataSource is the data source implementation of a group type
+type groupDataSource[G any] struct {
+	groupType[G]
+	membersSchema schema.Attribute
+	client        groupClient[G]
+}
+
+// newGroupDataSource creates a new data source for the group type, with
+// membersSchema defining its members attribute
+func newGroupDataSource[G any](t groupType[G], membersSchema schema.Attribute) datasource.DataSource {
+	return &groupDataSource[G]{groupType: t, membersSchema: membersSchema}
+}
+
+// Metadata returns the data source type name
+func (d *groupDataSource[G]) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + d.typeName
+}
+
+// Schema defines the schema for the data source
+func (d *groupDataSource[G]) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: fmt.Sprintf("Fetches a Sophos Firewall %s object", d.label),
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: fmt.Sprintf("Name of the %s", d.label),
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: fmt.Sprintf("Description of the %s", d.label),
+				Computed:    true,
+			},
+			d.members: d.membersSchema,
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *groupDataSource[G]) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*SophosClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *SophosClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = d.groupType.client(client.BaseClient)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *groupDataSource[G]) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	config := d.get(ctx, req.Config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := d.client.read(ctx, config.Name.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error reading "+d.label, err)
+		return
+	}
+
+	tflog.Debug(ctx, "Retrieved "+d.label, map[string]interface{}{"object": fmt.Sprintf("%+v", group)})
+
+	if group == nil {
+		resp.Diagnostics.AddError(
+			d.label+" not found",
+			fmt.Sprintf("%s with name %s not found", d.label, config.Name.ValueString()),
+		)
+		return
+	}
+
+	// Set the state
+	resp.State.Raw = req.Config.Raw
+	d.set(ctx, &resp.State, d.fromAPI(*group), &resp.Diagnostics)
+}
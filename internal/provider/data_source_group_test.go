@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testAccGroupDataSource reads the group stored as groupXML, which holds the
+// description and the updated members of gt, and a missing group
+func testAccGroupDataSource(t *testing.T, gt groupTest, groupXML string) {
+	server, providerConfig := testAccServer(t)
+	for _, entity := range append(slices.Clone(gt.entities), groupXML) {
+		if err := server.Put(entity); err != nil {
+			t.Fatal(err)
+		}
+	}
+	address := "data." + gt.resourceType + ".test"
+	// Lists of members are read sorted by name
+	members := slices.Clone(gt.updated)
+	if !gt.membersSet {
+		slices.Sort(members)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+data %q "test" {
+  name = %q
+}
+`, gt.resourceType, gt.name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(address, "description", gt.description),
+					gt.checkMembers(address, members),
+				),
+			},
+			{
+				Config: providerConfig + fmt.Sprintf(`
+data %q "test" {
+  name = "missing"
+}
+`, gt.resourceType),
+				ExpectError: regexp.MustCompile(`with name missing not found`),
+			},
+		},
+	})
+}
@@ -5,11 +5,11 @@ package provider
 import (
 	"context"
 	"fmt"
-	"log"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/iphost"
 )
 
@@ -33,46 +33,58 @@ func (d *ipHostDataSource) Metadata(_ context.Context, req datasource.MetadataRe
 
 // Schema defines the schema for the data source
 func (d *ipHostDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := ipHostDataSourceAttributes()
+	attributes["name"] = schema.StringAttribute{
+		Description: "Name of the IP Host",
+		Required:    true,
+	}
 	resp.Schema = schema.Schema{
 		Description: "Fetches a Sophos Firewall IP Host object",
-		Attributes: map[string]schema.Attribute{
-			"name": schema.StringAttribute{
-				Description: "Name of the IP Host",
-				Required:    true,
-			},
-			"ip_family": schema.StringAttribute{
-				Description: "IP Family (IPv4 or IPv6)",
-				Computed:    true,
-			},
-			"host_type": schema.StringAttribute{
-				Description: "Host Type (IP, Network, IPRange, IPList)",
-				Computed:    true,
-			},
-			"ip_address": schema.StringAttribute{
-				Description: "IP Address for IP or Network types",
-				Computed:    true,
-			},
-			"subnet": schema.StringAttribute{
-				Description: "Subnet mask for Network type",
-				Computed:    true,
-			},
-			"start_ip_address": schema.StringAttribute{
-				Description: "Start IP Address for IPRange type",
-				Computed:    true,
-			},
-			"end_ip_address": schema.StringAttribute{
-				Description: "End IP Address for IPRange type",
-				Computed:    true,
-			},
-			"list_of_ip_addresses": schema.StringAttribute{
-				Description: "Comma-separated list of IP addresses for IPList type",
-				Computed:    true,
-			},
-			"host_groups": schema.ListAttribute{
-				Description: "List of host groups this IP Host belongs to",
-				Computed:    true,
-				ElementType: types.StringType,
-			},
+		Attributes:  attributes,
+	}
+}
+
+// ipHostDataSourceAttributes returns the computed attributes of an IP Host
+// as read from the firewall, shared with the sophosfirewall_iphosts data
+// source
+func ipHostDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			Description: "Name of the IP Host",
+			Computed:    true,
+		},
+		"ip_family": schema.StringAttribute{
+			Description: "IP Family (IPv4 or IPv6)",
+			Computed:    true,
+		},
+		"host_type": schema.StringAttribute{
+			Description: "Host Type (IP, Network, IPRange, IPList)",
+			Computed:    true,
+		},
+		"ip_address": schema.StringAttribute{
+			Description: "IP Address for IP or Network types",
+			Computed:    true,
+		},
+		"subnet": schema.StringAttribute{
+			Description: "Subnet mask for Network type",
+			Computed:    true,
+		},
+		"start_ip_address": schema.StringAttribute{
+			Description: "Start IP Address for IPRange type",
+			Computed:    true,
+		},
+		"end_ip_address": schema.StringAttribute{
+			Description: "End IP Address for IPRange type",
+			Computed:    true,
+		},
+		"list_of_ip_addresses": schema.StringAttribute{
+			Description: "Comma-separated list of IP addresses for IPList type",
+			Computed:    true,
+		},
+		"host_groups": schema.ListAttribute{
+			Description: "List of host groups this IP Host belongs to",
+			Computed:    true,
+			ElementType: types.StringType,
 		},
 	}
 }
@@ -97,7 +109,7 @@ func (d *ipHostDataSource) Configure(_ context.Context, req datasource.Configure
 
 // Fixed Resource Read Function
 func (d *ipHostDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var config ipHostResourceModel
+	var config ipHostModel
 	
 	diags := req.Config.Get(ctx, &config)
 	resp.Diagnostics.Append(diags...)
@@ -106,13 +118,13 @@ func (d *ipHostDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 
 	// Get the IP Host from the API
-	ipHost, err := d.client.ReadIPHost(config.Name.ValueString())
+	ipHost, err := d.client.ReadIPHost(ctx, config.Name.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading IP Host", err.Error())
+		addClientError(&resp.Diagnostics, "Error reading IP Host", err)
 		return
 	}
 
-	log.Printf("[DEBUG] Data Source Read function - Retrieved IP Host: %+v", ipHost) // Log the retrieved object
+	tflog.Debug(ctx, "Retrieved IP Host", map[string]interface{}{"object": fmt.Sprintf("%+v", ipHost)})
 
 	if ipHost == nil {
 		resp.Diagnostics.AddError(
@@ -122,57 +134,7 @@ func (d *ipHostDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	// Always set these fields
-	config.Name = types.StringValue(ipHost.Name)
-	config.IPFamily = types.StringValue(ipHost.IPFamily)
-	config.HostType = types.StringValue(ipHost.HostType)
-	
-	// Handle field values based on the HostType to avoid drift
-	switch ipHost.HostType {
-	case "IP":
-		config.IPAddress = types.StringValue(ipHost.IPAddress)
-		config.Subnet = types.StringNull()
-		config.StartIPAddress = types.StringNull()
-		config.EndIPAddress = types.StringNull()
-		config.ListOfIPAddresses = types.StringNull()
-	case "Network":
-		config.IPAddress = types.StringValue(ipHost.IPAddress)
-		config.Subnet = types.StringValue(ipHost.Subnet)
-		config.StartIPAddress = types.StringNull()
-		config.EndIPAddress = types.StringNull()
-		config.ListOfIPAddresses = types.StringNull()
-	case "IPRange":
-		config.IPAddress = types.StringNull()
-		config.Subnet = types.StringNull()
-		config.StartIPAddress = types.StringValue(ipHost.StartIPAddress)
-		config.EndIPAddress = types.StringValue(ipHost.EndIPAddress)
-		config.ListOfIPAddresses = types.StringNull()
-	case "IPList":
-		config.IPAddress = types.StringNull()
-		config.Subnet = types.StringNull()
-		config.StartIPAddress = types.StringNull()
-		config.EndIPAddress = types.StringNull()
-		config.ListOfIPAddresses = types.StringValue(ipHost.ListOfIPAddresses)
-	case "System Host":
-		// For system hosts, set all type-specific fields to null
-		config.IPAddress = types.StringNull()
-		config.Subnet = types.StringNull()
-		config.StartIPAddress = types.StringNull()
-		config.EndIPAddress = types.StringNull()
-		config.ListOfIPAddresses = types.StringNull()
-	}
-
-	// Map host groups consistently
-	if ipHost.HostGroupList != nil && len(ipHost.HostGroupList.HostGroups) > 0 {
-		hostGroups := make([]types.String, 0, len(ipHost.HostGroupList.HostGroups))
-		for _, hg := range ipHost.HostGroupList.HostGroups {
-			hostGroups = append(hostGroups, types.StringValue(hg))
-		}
-		config.HostGroups = hostGroups
-	} else {
-		// Always initialize to empty slice
-		config.HostGroups = []types.String{}
-	}
+	config = apiToModelIPHost(*ipHost)
 
 	// Set the state
 	diags = resp.State.Set(ctx, &config)
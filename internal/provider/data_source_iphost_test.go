@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccIPHostDataSource(t *testing.T) {
+	server, providerConfig := testAccServer(t)
+	for _, entity := range []string{
+		`<IPHostGroup><Name>servers</Name><IPFamily>IPv4</IPFamily></IPHostGroup>`,
+		`<IPHost><Name>web</Name><IPFamily>IPv4</IPFamily><HostType>IP</HostType><IPAddress>10.0.0.1</IPAddress><HostGroupList><HostGroup>servers</HostGroup></HostGroupList></IPHost>`,
+	} {
+		if err := server.Put(entity); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "sophosfirewall_iphost" "test" {
+  name = "web"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sophosfirewall_iphost.test", "host_type", "IP"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_iphost.test", "ip_address", "10.0.0.1"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_iphost.test", "host_groups.0", "servers"),
+				),
+			},
+		},
+	})
+}
@@ -5,11 +5,11 @@ package provider
 import (
 	"context"
 	"fmt"
-	"log"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/iphostgroup"
 )
 
@@ -21,8 +21,8 @@ type ipHostGroupDataSource struct {
 	client *iphostgroup.Client
 }
 
-// NewipHostGroupDataSource creates a new data source
-func NewipHostGroupDataSource() datasource.DataSource {
+// NewIPHostGroupDataSource creates a new data source
+func NewIPHostGroupDataSource() datasource.DataSource {
 	return &ipHostGroupDataSource{}
 }
 
@@ -33,27 +33,38 @@ func (d *ipHostGroupDataSource) Metadata(_ context.Context, req datasource.Metad
 
 // Schema defines the schema for the data source
 func (d *ipHostGroupDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := ipHostGroupDataSourceAttributes()
+	attributes["name"] = schema.StringAttribute{
+		Description: "Name of the IP Host Group",
+		Required:    true,
+	}
 	resp.Schema = schema.Schema{
 		Description: "Fetches a Sophos Firewall IP Host Group object",
-		Attributes: map[string]schema.Attribute{
-			"name": schema.StringAttribute{
-				Description: "Name of the IP Host",
-				Required:    true,
-			},
-			"ip_family": schema.StringAttribute{
-				Description: "IP Family (IPv4 or IPv6)",
-				Computed:    true,
-			},
-			"description": schema.StringAttribute{
-				Description: "Description of Host Group",
-				Optional:    true,
-				Computed:    true,
-			},
-			"host_list": schema.ListAttribute{
-				Description: "List of host groups this IP Host belongs to",
-				Computed:    true,
-				ElementType: types.StringType,
-			},
+		Attributes:  attributes,
+	}
+}
+
+// ipHostGroupDataSourceAttributes returns the computed attributes of an IP
+// Host Group as read from the firewall, shared with the
+// sophosfirewall_iphostgroups data source
+func ipHostGroupDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			Description: "Name of the IP Host Group",
+			Computed:    true,
+		},
+		"ip_family": schema.StringAttribute{
+			Description: "IP Family (IPv4 or IPv6)",
+			Computed:    true,
+		},
+		"description": schema.StringAttribute{
+			Description: "Description of Host Group",
+			Computed:    true,
+		},
+		"host_list": schema.ListAttribute{
+			Description: "Sorted list of the IP Hosts in the group",
+			Computed:    true,
+			ElementType: types.StringType,
 		},
 	}
 }
@@ -78,7 +89,7 @@ func (d *ipHostGroupDataSource) Configure(_ context.Context, req datasource.Conf
 
 // Fixed Resource Read Function
 func (d *ipHostGroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var config ipHostGroupResourceModel
+	var config ipHostGroupModel
 	
 	diags := req.Config.Get(ctx, &config)
 	resp.Diagnostics.Append(diags...)
@@ -87,13 +98,13 @@ func (d *ipHostGroupDataSource) Read(ctx context.Context, req datasource.ReadReq
 	}
 
 	// Get the IP Host from the API
-	ipHostGroup, err := d.client.ReadIPHostGroup(config.Name.ValueString())
+	ipHostGroup, err := d.client.ReadIPHostGroup(ctx, config.Name.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading IP Host Group", err.Error())
+		addClientError(&resp.Diagnostics, "Error reading IP Host Group", err)
 		return
 	}
 
-	log.Printf("[DEBUG] Data Source Read function - Retrieved IP Host Group: %+v", ipHostGroup) // Log the retrieved object
+	tflog.Debug(ctx, "Retrieved IP Host Group", map[string]interface{}{"object": fmt.Sprintf("%+v", ipHostGroup)})
 
 	if ipHostGroup == nil {
 		resp.Diagnostics.AddError(
@@ -103,22 +114,7 @@ func (d *ipHostGroupDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	// Always set these fields
-	config.Name = types.StringValue(ipHostGroup.Name)
-	config.IPFamily = types.StringValue(ipHostGroup.IPFamily)
-	config.Description = types.StringValue(ipHostGroup.Description)
-
-	// Map host groups consistently
-	if ipHostGroup.HostList != nil && len(ipHostGroup.HostList.Hosts) > 0 {
-		hosts := make([]types.String, 0, len(ipHostGroup.HostList.Hosts))
-		for _, hg := range ipHostGroup.HostList.Hosts {
-			hosts = append(hosts, types.StringValue(hg))
-		}
-		config.Hosts = hosts
-	} else {
-		// Always initialize to empty slice
-		config.Hosts = []types.String{}
-	}
+	config = apiToModelIPHostGroup(*ipHostGroup)
 
 	// Set the state
 	diags = resp.State.Set(ctx, &config)
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccIPHostGroupDataSource(t *testing.T) {
+	server, providerConfig := testAccServer(t)
+	for _, entity := range []string{
+		`<IPHost><Name>web</Name><IPFamily>IPv4</IPFamily><HostType>IP</HostType><IPAddress>10.0.0.1</IPAddress></IPHost>`,
+		`<IPHost><Name>app</Name><IPFamily>IPv4</IPFamily><HostType>IP</HostType><IPAddress>10.0.0.2</IPAddress></IPHost>`,
+		`<IPHostGroup><Name>servers</Name><Description>All servers</Description><IPFamily>IPv4</IPFamily><HostList><Host>web</Host><Host>app</Host></HostList></IPHostGroup>`,
+	} {
+		if err := server.Put(entity); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "sophosfirewall_iphostgroup" "test" {
+  name = "servers"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sophosfirewall_iphostgroup.test", "ip_family", "IPv4"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_iphostgroup.test", "description", "All servers"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_iphostgroup.test", "host_list.#", "2"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_iphostgroup.test", "host_list.0", "app"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_iphostgroup.test", "host_list.1", "web"),
+				),
+			},
+			{
+				Config: providerConfig + `
+data "sophosfirewall_iphostgroup" "test" {
+  name = "missing"
+}
+`,
+				ExpectError: regexp.MustCompile(`IP Host Group not found`),
+			},
+		},
+	})
+}
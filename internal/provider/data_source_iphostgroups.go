@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/iphostgroup"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var _ datasource.DataSource = &ipHostGroupsDataSource{}
+
+// ipHostGroupsDataSource is the data source implementation
+type ipHostGroupsDataSource struct {
+	client *iphostgroup.Client
+}
+
+// ipHostGroupsDataSourceModel maps the data source schema data
+type ipHostGroupsDataSourceModel struct {
+	NameRegex    types.String       `tfsdk:"name_regex"`
+	IPFamily     types.String       `tfsdk:"ip_family"`
+	Host         types.String       `tfsdk:"host"`
+	Names        []types.String     `tfsdk:"names"`
+	IPHostGroups []ipHostGroupModel `tfsdk:"iphostgroups"`
+}
+
+// NewIPHostGroupsDataSource creates a new data source
+func NewIPHostGroupsDataSource() datasource.DataSource {
+	return &ipHostGroupsDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *ipHostGroupsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iphostgroups"
+}
+
+// Schema defines the schema for the data source
+func (d *ipHostGroupsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches the Sophos Firewall IP Host Group objects matching the filters",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": nameRegexAttribute,
+			"ip_family":  filterAttribute("IP Family the IP Host Groups must have (IPv4 or IPv6)"),
+			"host":       filterAttribute("Name of an IP Host the IP Host Groups must contain"),
+			"names": schema.ListAttribute{
+				Description: "Sorted names of the matching IP Host Groups",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"iphostgroups": schema.ListNestedAttribute{
+				Description: "Matching IP Host Groups, sorted by name",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: ipHostGroupDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *ipHostGroupsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*SophosClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *SophosClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = iphostgroup.NewClient(client.BaseClient)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *ipHostGroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config ipHostGroupsDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex := compileNameRegex(config.NameRegex, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ipHostGroups, err := d.client.ListIPHostGroups(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error listing IP Host Groups", err)
+		return
+	}
+
+	tflog.Debug(ctx, "Retrieved IP Host Groups", map[string]interface{}{"count": len(ipHostGroups)})
+
+	sort.Slice(ipHostGroups, func(i, j int) bool { return ipHostGroups[i].Name < ipHostGroups[j].Name })
+	config.Names = []types.String{}
+	config.IPHostGroups = []ipHostGroupModel{}
+	for _, ipHostGroup := range ipHostGroups {
+		model := apiToModelIPHostGroup(ipHostGroup)
+		if !matchName(nameRegex, ipHostGroup.Name) ||
+			!matchFilter(config.IPFamily, ipHostGroup.IPFamily) ||
+			!matchMember(config.Host, model.Hosts) {
+			continue
+		}
+		config.Names = append(config.Names, types.StringValue(ipHostGroup.Name))
+		config.IPHostGroups = append(config.IPHostGroups, model)
+	}
+
+	// Set the state
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
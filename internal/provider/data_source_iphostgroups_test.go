@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccIPHostGroupsDataSource(t *testing.T) {
+	server, providerConfig := testAccServer(t)
+	for _, entity := range []string{
+		`<IPHost><Name>web</Name><IPFamily>IPv4</IPFamily><HostType>IP</HostType><IPAddress>10.0.0.1</IPAddress></IPHost>`,
+		`<IPHostGroup><Name>servers</Name><IPFamily>IPv4</IPFamily><HostList><Host>web</Host></HostList></IPHostGroup>`,
+		`<IPHostGroup><Name>empty</Name><IPFamily>IPv4</IPFamily></IPHostGroup>`,
+		`<IPHostGroup><Name>servers6</Name><IPFamily>IPv6</IPFamily></IPHostGroup>`,
+	} {
+		if err := server.Put(entity); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "sophosfirewall_iphostgroups" "ipv4" {
+  ip_family = "IPv4"
+}
+
+data "sophosfirewall_iphostgroups" "with_web" {
+  host = "web"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sophosfirewall_iphostgroups.ipv4", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_iphostgroups.ipv4", "names.0", "empty"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_iphostgroups.ipv4", "names.1", "servers"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_iphostgroups.with_web", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_iphostgroups.with_web", "iphostgroups.0.host_list.0", "web"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/iphost"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var _ datasource.DataSource = &ipHostsDataSource{}
+
+// ipHostsDataSource is the data source implementation
+type ipHostsDataSource struct {
+	client *iphost.Client
+}
+
+// ipHostsDataSourceModel maps the data source schema data
+type ipHostsDataSourceModel struct {
+	NameRegex types.String   `tfsdk:"name_regex"`
+	HostType  types.String   `tfsdk:"host_type"`
+	IPFamily  types.String   `tfsdk:"ip_family"`
+	HostGroup types.String   `tfsdk:"host_group"`
+	Names     []types.String `tfsdk:"names"`
+	IPHosts   []ipHostModel  `tfsdk:"iphosts"`
+}
+
+// NewIPHostsDataSource creates a new data source
+func NewIPHostsDataSource() datasource.DataSource {
+	return &ipHostsDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *ipHostsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iphosts"
+}
+
+// Schema defines the schema for the data source
+func (d *ipHostsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches the Sophos Firewall IP Host objects matching the filters",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": nameRegexAttribute,
+			"host_type":  filterAttribute("Host Type the IP Hosts must have (IP, Network, IPRange, IPList)"),
+			"ip_family":  filterAttribute("IP Family the IP Hosts must have (IPv4 or IPv6)"),
+			"host_group": filterAttribute("Name of a host group the IP Hosts must belong to"),
+			"names": schema.ListAttribute{
+				Description: "Sorted names of the matching IP Hosts",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"iphosts": schema.ListNestedAttribute{
+				Description: "Matching IP Hosts, sorted by name",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: ipHostDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *ipHostsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*SophosClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *SophosClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = iphost.NewClient(client.BaseClient)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *ipHostsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config ipHostsDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex := compileNameRegex(config.NameRegex, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ipHosts, err := d.client.ListIPHosts(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error listing IP Hosts", err)
+		return
+	}
+
+	tflog.Debug(ctx, "Retrieved IP Hosts", map[string]interface{}{"count": len(ipHosts)})
+
+	sort.Slice(ipHosts, func(i, j int) bool { return ipHosts[i].Name < ipHosts[j].Name })
+	config.Names = []types.String{}
+	config.IPHosts = []ipHostModel{}
+	for _, ipHost := range ipHosts {
+		model := apiToModelIPHost(ipHost)
+		if !matchName(nameRegex, ipHost.Name) ||
+			!matchFilter(config.HostType, ipHost.HostType) ||
+			!matchFilter(config.IPFamily, ipHost.IPFamily) ||
+			!matchMember(config.HostGroup, model.HostGroups) {
+			continue
+		}
+		config.Names = append(config.Names, types.StringValue(ipHost.Name))
+		config.IPHosts = append(config.IPHosts, model)
+	}
+
+	// Set the state
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
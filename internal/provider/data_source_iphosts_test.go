@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccIPHostsDataSource(t *testing.T) {
+	server, providerConfig := testAccServer(t)
+	for _, entity := range []string{
+		`<IPHost><Name>web</Name><IPFamily>IPv4</IPFamily><HostType>IP</HostType><IPAddress>10.0.0.1</IPAddress></IPHost>`,
+		`<IPHost><Name>app</Name><IPFamily>IPv4</IPFamily><HostType>Network</HostType><IPAddress>10.0.1.0</IPAddress><Subnet>255.255.255.0</Subnet></IPHost>`,
+		`<IPHost><Name>db6</Name><IPFamily>IPv6</IPFamily><HostType>IP</HostType><IPAddress>2001:db8::1</IPAddress></IPHost>`,
+		`<IPHostGroup><Name>servers</Name><IPFamily>IPv4</IPFamily><HostList><Host>web</Host><Host>app</Host></HostList></IPHostGroup>`,
+	} {
+		if err := server.Put(entity); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "sophosfirewall_iphosts" "all" {}
+
+data "sophosfirewall_iphosts" "servers" {
+  host_group = "servers"
+  host_type  = "ip"
+}
+
+data "sophosfirewall_iphosts" "regex" {
+  name_regex = "^(app|db)"
+  ip_family  = "IPv4"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sophosfirewall_iphosts.all", "names.#", "3"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_iphosts.all", "names.0", "app"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_iphosts.all", "iphosts.2.ip_address", "10.0.0.1"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_iphosts.servers", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_iphosts.servers", "iphosts.0.name", "web"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_iphosts.servers", "iphosts.0.host_groups.0", "servers"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_iphosts.regex", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_iphosts.regex", "iphosts.0.subnet", "255.255.255.0"),
+				),
+			},
+			{
+				Config: providerConfig + `
+data "sophosfirewall_iphosts" "test" {
+  name_regex = "("
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Name Regular Expression`),
+			},
+		},
+	})
+}
@@ -5,11 +5,11 @@ package provider
 import (
 	"context"
 	"fmt"
-	"log"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/machost"
 )
 
@@ -33,31 +33,46 @@ func (d *macHostDataSource) Metadata(_ context.Context, req datasource.MetadataR
 
 // Schema defines the schema for the data source
 func (d *macHostDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := macHostDataSourceAttributes()
+	attributes["name"] = schema.StringAttribute{
+		Description: "Name of the MAC Host",
+		Required:    true,
+	}
 	resp.Schema = schema.Schema{
 		Description: "Fetches a Sophos Firewall MAC Host object",
-		Attributes: map[string]schema.Attribute{
-			"name": schema.StringAttribute{
-				Description: "Name of the MAC Host",
-				Required:    true,
-			},
-			"description": schema.StringAttribute{
-				Description: "Description of the MAC Host",
-				Optional:    true,
-				Computed:    true,
-			},
-			"type": schema.StringAttribute{
-				Description: "MAC Host Type (MACAddress, MACLIST)",
-				Computed:    true,
-			},
-			"mac_address": schema.StringAttribute{
-				Description: "MAC Address for Single MAC",
-				Computed:    true,
-			},
-			"list_of_mac_addresses": schema.ListAttribute{
-				Description: "List of MAC addresses for MACList type",
-				Computed:    true,
-				ElementType: types.StringType,
-			},
+		Attributes:  attributes,
+	}
+}
+
+// macHostDataSourceAttributes returns the computed attributes of a MAC Host
+// as read from the firewall, shared with the sophosfirewall_machosts data
+// source
+func macHostDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			Description: "Name of the MAC Host",
+			Computed:    true,
+		},
+		"description": schema.StringAttribute{
+			Description: "Description of the MAC Host",
+			Computed:    true,
+		},
+		"type": schema.StringAttribute{
+			Description: "MAC Host Type (MACAddress, MACLIST)",
+			Computed:    true,
+		},
+		"mac_address": schema.StringAttribute{
+			Description: "MAC Address for Single MAC",
+			Computed:    true,
+		},
+		"list_of_mac_addresses": schema.StringAttribute{
+			Description: "Comma-separated list of MAC addresses for MACList type",
+			Computed:    true,
+		},
+		"host_groups": schema.ListAttribute{
+			Description: "List of MAC Host Groups this MAC Host belongs to",
+			Computed:    true,
+			ElementType: types.StringType,
 		},
 	}
 }
@@ -80,18 +95,9 @@ func (d *macHostDataSource) Configure(_ context.Context, req datasource.Configur
 	d.client = machost.NewClient(client.BaseClient)
 }
 
-// macHostDataSourceModel maps the data source schema data
-type macHostDataSourceModel struct {
-	Name               types.String `tfsdk:"name"`
-	Description        types.String `tfsdk:"description"`
-	Type               types.String `tfsdk:"type"`
-	MACAddress         types.String `tfsdk:"mac_address"`
-	ListOfMACAddresses types.List   `tfsdk:"list_of_mac_addresses"`
-}
-
 // Read refreshes the Terraform state with the latest data
 func (d *macHostDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var config macHostDataSourceModel
+	var config macHostModel
 	diags := req.Config.Get(ctx, &config)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -99,14 +105,13 @@ func (d *macHostDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}
 
 	// Get the MAC Host from the API
-	macHost, err := d.client.ReadMACHost(config.Name.ValueString())
+	macHost, err := d.client.ReadMACHost(ctx, config.Name.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading MAC Host", err.Error())
+		addClientError(&resp.Diagnostics, "Error reading MAC Host", err)
 		return
 	}
 
-	log.Printf("[DEBUG] Read function - Retrieved MAC Host: %+v", macHost) // Log the retrieved object
-
+	tflog.Debug(ctx, "Retrieved MAC Host", map[string]interface{}{"object": fmt.Sprintf("%+v", macHost)})
 
 	if macHost == nil {
 		resp.Diagnostics.AddError(
@@ -117,37 +122,7 @@ func (d *macHostDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}
 
 	// Map the data from the API model to the data source model
-	config.Type = types.StringValue(macHost.Type)
-	if macHost.Description != "" {
-		config.Description = types.StringValue(macHost.Description)
-	} else {
-		config.Description = types.StringNull()
-	}
-
-	// Handle MACAddress type
-	if macHost.Type == "MACAddress" {
-		config.MACAddress = types.StringValue(macHost.MACAddress)
-		config.ListOfMACAddresses = types.ListNull(types.StringType) // Ensure the list is null
-	} else if macHost.Type == "MACLIST" {
-		config.MACAddress = types.StringNull() // Ensure the single address is null
-		if macHost.ListOfMACAddresses != nil {
-			elements := make([]types.String, 0, len(macHost.ListOfMACAddresses))
-			for _, mac := range macHost.ListOfMACAddresses {
-				elements = append(elements, types.StringValue(mac))
-			}
-			listVal, diags := types.ListValueFrom(ctx, types.StringType, elements)
-			resp.Diagnostics.Append(diags...)
-			if !resp.Diagnostics.HasError() {
-				config.ListOfMACAddresses = listVal
-			}
-		} else {
-			config.ListOfMACAddresses = types.ListNull(types.StringType)
-		}
-	} else {
-		// Handle unknown type (shouldn't happen ideally, but for robustness)
-		config.MACAddress = types.StringNull()
-		config.ListOfMACAddresses = types.ListNull(types.StringType)
-	}
+	config = apiToModelMACHost(*macHost)
 
 	// Set the state
 	diags = resp.State.Set(ctx, &config)
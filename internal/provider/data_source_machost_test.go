@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccMACHostDataSource(t *testing.T) {
+	server, providerConfig := testAccServer(t)
+	for _, entity := range []string{
+		`<MACHost><Name>printer</Name><Description>Office printer</Description><Type>MACAddress</Type><MACAddress>00:11:22:33:44:55</MACAddress></MACHost>`,
+		`<MACHost><Name>printers</Name><Type>MACLIST</Type><MACList><MACAddress>00:11:22:33:44:55</MACAddress><MACAddress>00:11:22:33:44:66</MACAddress></MACList></MACHost>`,
+		`<MACHostGroup><Name>office</Name><MACHostList><MACHost>printer</MACHost></MACHostList></MACHostGroup>`,
+	} {
+		if err := server.Put(entity); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "sophosfirewall_machost" "single" {
+  name = "printer"
+}
+
+data "sophosfirewall_machost" "list" {
+  name = "printers"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sophosfirewall_machost.single", "type", "MACAddress"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_machost.single", "description", "Office printer"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_machost.single", "mac_address", "00:11:22:33:44:55"),
+					resource.TestCheckNoResourceAttr("data.sophosfirewall_machost.single", "list_of_mac_addresses"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_machost.single", "host_groups.0", "office"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_machost.list", "host_groups.#", "0"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_machost.list", "type", "MACLIST"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_machost.list", "list_of_mac_addresses", "00:11:22:33:44:55,00:11:22:33:44:66"),
+					resource.TestCheckNoResourceAttr("data.sophosfirewall_machost.list", "mac_address"),
+				),
+			},
+			{
+				Config: providerConfig + `
+data "sophosfirewall_machost" "test" {
+  name = "missing"
+}
+`,
+				ExpectError: regexp.MustCompile(`MAC Host not found`),
+			},
+		},
+	})
+}
@@ -0,0 +1,16 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// NewMACHostGroupDataSource creates a new data source
+func NewMACHostGroupDataSource() datasource.DataSource {
+	return newGroupDataSource(macHostGroupType, schema.ListAttribute{
+		Description: "List of MAC Hosts this MAC Host Group refers to",
+		Computed:    true,
+		ElementType: types.StringType,
+	})
+}
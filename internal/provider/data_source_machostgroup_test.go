@@ -0,0 +1,8 @@
+package provider
+
+import "testing"
+
+func TestAccMACHostGroupDataSource(t *testing.T) {
+	testAccGroupDataSource(t, macHostGroupTest,
+		`<MACHostGroup><Name>printers</Name><Description>Office printers</Description><MACHostList><MACHost>printer2</MACHost><MACHost>printer1</MACHost></MACHostList></MACHostGroup>`)
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/machost"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var _ datasource.DataSource = &macHostsDataSource{}
+
+// macHostsDataSource is the data source implementation
+type macHostsDataSource struct {
+	client *machost.Client
+}
+
+// macHostsDataSourceModel maps the data source schema data
+type macHostsDataSourceModel struct {
+	NameRegex types.String   `tfsdk:"name_regex"`
+	Type      types.String   `tfsdk:"type"`
+	Names     []types.String `tfsdk:"names"`
+	MACHosts  []macHostModel `tfsdk:"machosts"`
+}
+
+// NewMACHostsDataSource creates a new data source
+func NewMACHostsDataSource() datasource.DataSource {
+	return &macHostsDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *macHostsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_machosts"
+}
+
+// Schema defines the schema for the data source
+func (d *macHostsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches the Sophos Firewall MAC Host objects matching the filters",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": nameRegexAttribute,
+			"type":       filterAttribute("Type the MAC Hosts must have (MACAddress, MACLIST)"),
+			"names": schema.ListAttribute{
+				Description: "Sorted names of the matching MAC Hosts",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"machosts": schema.ListNestedAttribute{
+				Description: "Matching MAC Hosts, sorted by name",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: macHostDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *macHostsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*SophosClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *SophosClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = machost.NewClient(client.BaseClient)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *macHostsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config macHostsDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex := compileNameRegex(config.NameRegex, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	macHosts, err := d.client.ListMACHosts(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error listing MAC Hosts", err)
+		return
+	}
+
+	tflog.Debug(ctx, "Retrieved MAC Hosts", map[string]interface{}{"count": len(macHosts)})
+
+	sort.Slice(macHosts, func(i, j int) bool { return macHosts[i].Name < macHosts[j].Name })
+	config.Names = []types.String{}
+	config.MACHosts = []macHostModel{}
+	for _, macHost := range macHosts {
+		if !matchName(nameRegex, macHost.Name) || !matchFilter(config.Type, macHost.Type) {
+			continue
+		}
+		config.Names = append(config.Names, types.StringValue(macHost.Name))
+		config.MACHosts = append(config.MACHosts, apiToModelMACHost(macHost))
+	}
+
+	// Set the state
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
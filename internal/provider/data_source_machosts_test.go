@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccMACHostsDataSource(t *testing.T) {
+	server, providerConfig := testAccServer(t)
+	for _, entity := range []string{
+		`<MACHost><Name>camera</Name><Type>MACAddress</Type><MACAddress>00:16:76:49:33:CE</MACAddress></MACHost>`,
+		`<MACHost><Name>printers</Name><Type>MACLIST</Type><MACList><MACAddress>00:11:22:33:44:55</MACAddress><MACAddress>00:11:22:33:44:66</MACAddress></MACList></MACHost>`,
+	} {
+		if err := server.Put(entity); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "sophosfirewall_machosts" "all" {}
+
+data "sophosfirewall_machosts" "lists" {
+  type = "MACLIST"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sophosfirewall_machosts.all", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_machosts.all", "machosts.0.mac_address", "00:16:76:49:33:CE"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_machosts.lists", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_machosts.lists", "machosts.0.list_of_mac_addresses", "00:11:22:33:44:55,00:11:22:33:44:66"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/service"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var _ datasource.DataSource = &serviceDataSource{}
+
+// serviceDataSource is the data source implementation
+type serviceDataSource struct {
+	client *service.Client
+}
+
+// NewServiceDataSource creates a new data source
+func NewServiceDataSource() datasource.DataSource {
+	return &serviceDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *serviceDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service"
+}
+
+// Schema defines the schema for the data source
+func (d *serviceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches a Sophos Firewall Service object",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "Name of the Service",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of the Service",
+				Computed:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Service Type (TCPorUDP, IP, ICMP, ICMPv6)",
+				Computed:    true,
+			},
+			"service_details": schema.ListNestedAttribute{
+				Description: "Protocols and ports the Service matches",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"protocol": schema.StringAttribute{
+							Description: "Protocol (TCP or UDP) of TCPorUDP services",
+							Computed:    true,
+						},
+						"source_port": schema.StringAttribute{
+							Description: "Source port or port range of TCPorUDP services",
+							Computed:    true,
+						},
+						"destination_port": schema.StringAttribute{
+							Description: "Destination port or port range of TCPorUDP services",
+							Computed:    true,
+						},
+						"protocol_name": schema.StringAttribute{
+							Description: "IP protocol name of IP services",
+							Computed:    true,
+						},
+						"icmp_type": schema.StringAttribute{
+							Description: "ICMP type of ICMP and ICMPv6 services",
+							Computed:    true,
+						},
+						"icmp_code": schema.StringAttribute{
+							Description: "ICMP code of ICMP and ICMPv6 services",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *serviceDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*SophosClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *SophosClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = service.NewClient(client.BaseClient)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *serviceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config serviceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	svc, err := d.client.ReadService(ctx, config.Name.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error reading Service", err)
+		return
+	}
+
+	tflog.Debug(ctx, "Retrieved Service", map[string]interface{}{"object": fmt.Sprintf("%+v", svc)})
+
+	if svc == nil {
+		resp.Diagnostics.AddError(
+			"Service not found",
+			fmt.Sprintf("Service with name %s not found", config.Name.ValueString()),
+		)
+		return
+	}
+
+	config = apiToModelService(*svc)
+
+	// Set the state
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,16 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// NewServiceGroupDataSource creates a new data source
+func NewServiceGroupDataSource() datasource.DataSource {
+	return newGroupDataSource(serviceGroupType, schema.SetAttribute{
+		Description: "Set of services in the Service Group",
+		Computed:    true,
+		ElementType: types.StringType,
+	})
+}
@@ -0,0 +1,8 @@
+package provider
+
+import "testing"
+
+func TestAccServiceGroupDataSource(t *testing.T) {
+	testAccGroupDataSource(t, serviceGroupTest,
+		`<ServiceGroup><Name>web</Name><Description>Web services</Description><ServiceList><Service>https</Service><Service>http</Service></ServiceList></ServiceGroup>`)
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccServiceDataSource(t *testing.T) {
+	server, providerConfig := testAccServer(t)
+	for _, entity := range []string{
+		`<Services><Name>web</Name><Description>Web traffic</Description><Type>TCPorUDP</Type><ServiceDetails>` +
+			`<ServiceDetail><SourcePort>1:65535</SourcePort><DestinationPort>443</DestinationPort><Protocol>TCP</Protocol></ServiceDetail>` +
+			`</ServiceDetails></Services>`,
+		`<Services><Name>gre</Name><Type>IP</Type><ServiceDetails>` +
+			`<ServiceDetail><ProtocolName>GRE</ProtocolName></ServiceDetail>` +
+			`</ServiceDetails></Services>`,
+	} {
+		if err := server.Put(entity); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "sophosfirewall_service" "web" {
+  name = "web"
+}
+
+data "sophosfirewall_service" "gre" {
+  name = "gre"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sophosfirewall_service.web", "type", "TCPorUDP"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_service.web", "description", "Web traffic"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_service.web", "service_details.0.protocol", "TCP"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_service.web", "service_details.0.destination_port", "443"),
+					resource.TestCheckNoResourceAttr("data.sophosfirewall_service.web", "service_details.0.protocol_name"),
+					resource.TestCheckResourceAttr("data.sophosfirewall_service.gre", "service_details.0.protocol_name", "GRE"),
+					resource.TestCheckNoResourceAttr("data.sophosfirewall_service.gre", "service_details.0.destination_port"),
+				),
+			},
+			{
+				Config: providerConfig + `
+data "sophosfirewall_service" "test" {
+  name = "missing"
+}
+`,
+				ExpectError: regexp.MustCompile(`Service not found`),
+			},
+		},
+	})
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/common"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var _ datasource.DataSource = &systemInfoDataSource{}
+
+// systemInfoDataSource is the data source implementation
+type systemInfoDataSource struct {
+	client *common.BaseClient
+}
+
+// systemInfoModel maps the data source schema data
+type systemInfoModel struct {
+	Endpoint   types.String `tfsdk:"endpoint"`
+	APIVersion types.String `tfsdk:"api_version"`
+}
+
+// NewSystemInfoDataSource creates a new data source
+func NewSystemInfoDataSource() datasource.DataSource {
+	return &systemInfoDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *systemInfoDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_system_info"
+}
+
+// Schema defines the schema for the data source
+func (d *systemInfoDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches information about the Sophos Firewall the provider is connected to",
+		Attributes: map[string]schema.Attribute{
+			"endpoint": schema.StringAttribute{
+				Description: "URL of the XML API",
+				Computed:    true,
+			},
+			"api_version": schema.StringAttribute{
+				Description: "XML API version reported by the firmware, e.g. 2000.1",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *systemInfoDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*SophosClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *SophosClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client.BaseClient
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *systemInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	info, err := d.client.Login(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error reading system info", err)
+		return
+	}
+
+	apiVersion := d.client.APIVersion()
+	if apiVersion == "" {
+		apiVersion = info.APIVersion
+	}
+	state := systemInfoModel{
+		Endpoint:   types.StringValue(d.client.URL),
+		APIVersion: types.StringValue(apiVersion),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
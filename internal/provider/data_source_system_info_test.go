@@ -0,0 +1,24 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSystemInfoDataSource(t *testing.T) {
+	server, providerConfig := testAccServer(t)
+	server.SetAPIVersion("1905.1")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `data "sophosfirewall_system_info" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sophosfirewall_system_info.test", "api_version", "1905.1"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/common"
+)
+
+// addClientError appends an error diagnostic for err, explaining the typed
+// Sophos API errors so users know which side has to be fixed
+func addClientError(diags *diag.Diagnostics, summary string, err error) {
+	var (
+		authErr       *common.AuthError
+		permissionErr *common.PermissionError
+		dependencyErr *common.DependencyError
+		validationErr *common.ValidationError
+	)
+
+	switch {
+	case errors.As(err, &authErr):
+		diags.AddError(summary, "The Sophos Firewall rejected the API credentials. "+
+			"Check the provider username and password.\n\n"+err.Error())
+	case errors.As(err, &permissionErr):
+		diags.AddError(summary, "The Sophos Firewall does not allow this operation. "+
+			"Check that API access is enabled for the source IP address of Terraform "+
+			"and that the administrator profile permits the change.\n\n"+err.Error())
+	case errors.As(err, &dependencyErr):
+		diags.AddError(summary, "The object is still in use by other entities on the Sophos Firewall. "+
+			"Remove those references first.\n\n"+err.Error())
+	case errors.As(err, &validationErr):
+		diags.AddError(summary, "The Sophos Firewall rejected the configuration. "+
+			"Check the attribute values.\n\n"+err.Error())
+	default:
+		diags.AddError(summary, err.Error())
+	}
+}
+
+// isNotFound reports whether err means the object does not exist on the appliance
+func isNotFound(err error) bool {
+	var notFoundErr *common.NotFoundError
+	return errors.As(err, &notFoundErr)
+}
+
+// addClientConfigError reports an error of NewSophosClient on the provider
+// attribute that caused it
+func addClientConfigError(diags *diag.Diagnostics, config SophosProviderModel, err error) {
+	var configErr *common.ConfigError
+	switch {
+	case errors.As(err, &configErr):
+		diags.AddAttributeError(path.Root(configErr.Setting), "Invalid Provider Configuration", err.Error())
+	case errors.Is(err, common.ErrNoCACerts):
+		attribute := "ca_cert_pem"
+		if !config.CACertFile.IsNull() {
+			attribute = "ca_cert_file"
+		}
+		diags.AddAttributeError(path.Root(attribute), "Invalid CA Certificate", err.Error())
+	default:
+		diags.AddError("Unable to Create Sophos Client", err.Error())
+	}
+}
+
+// addLoginError reports a failed login probe with the likely cause
+func addLoginError(diags *diag.Diagnostics, url string, err error) {
+	var (
+		authErr       *common.AuthError
+		permissionErr *common.PermissionError
+		apiErr        *common.APIError
+	)
+
+	switch {
+	case errors.As(err, &authErr):
+		diags.AddError("Sophos Firewall Authentication Failed",
+			"The Sophos Firewall rejected the API credentials. Check the provider username and password, "+
+				"and that the administrator account is not locked out.\n\n"+err.Error())
+	case errors.As(err, &permissionErr):
+		diags.AddError("Sophos Firewall API Access Not Allowed",
+			"The Sophos Firewall does not accept API requests from this host. Enable API configuration "+
+				"under Backup & firmware > API and add the source IP address of Terraform to the allowed "+
+				"IP addresses.\n\n"+err.Error())
+	case errors.As(err, &apiErr):
+		diags.AddError("Sophos Firewall Login Failed", err.Error())
+	default:
+		diags.AddError("Unable to Reach Sophos Firewall",
+			fmt.Sprintf("The XML API at %s could not be reached. Check the endpoint, port, api_path, "+
+				"proxy and TLS settings of the provider.\n\n%s", url, err))
+	}
+}
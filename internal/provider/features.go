@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/common"
+)
+
+// attributeFeature is an attribute that only exists from a firmware version on
+type attributeFeature struct {
+	attribute     path.Path
+	minAPIVersion string
+	firmware      string
+}
+
+// firewallRuleFeatures lists the firewall rule attributes missing on older
+// firmware, in the network_policy and the user_policy block
+var firewallRuleFeatures = append(
+	policyFeatures(path.Root("network_policy")),
+	policyFeatures(path.Root("user_policy"))...,
+)
+
+// policyFeatures lists the policy attributes missing on older firmware
+func policyFeatures(block path.Path) []attributeFeature {
+	return []attributeFeature{
+		{attribute: block.AtName("web_category_base_qos_policy"), minAPIVersion: "1700.1", firmware: "SFOS 17.0"},
+		{attribute: block.AtName("source_security_heartbeat"), minAPIVersion: "1700.1", firmware: "SFOS 17.0"},
+		{attribute: block.AtName("minimum_source_hb_permitted"), minAPIVersion: "1700.1", firmware: "SFOS 17.0"},
+		{attribute: block.AtName("dest_security_heartbeat"), minAPIVersion: "1700.1", firmware: "SFOS 17.0"},
+		{attribute: block.AtName("minimum_destination_hb_permitted"), minAPIVersion: "1700.1", firmware: "SFOS 17.0"},
+		{attribute: block.AtName("block_quick_quic"), minAPIVersion: "1800.1", firmware: "SFOS 18.0"},
+	}
+}
+
+// checkFeatures rejects configured attributes that the firmware of the
+// appliance does not support. Unset attributes are not sent to the appliance.
+func checkFeatures(ctx context.Context, diags *diag.Diagnostics, config tfsdk.Config, apiVersion string, features []attributeFeature) {
+	if apiVersion == "" {
+		return
+	}
+	for _, feature := range features {
+		if common.APIVersionAtLeast(apiVersion, feature.minAPIVersion) {
+			continue
+		}
+
+		var value attr.Value
+		diags.Append(config.GetAttribute(ctx, feature.attribute, &value)...)
+		if value == nil || value.IsNull() {
+			continue
+		}
+		diags.AddAttributeError(
+			feature.attribute,
+			"Attribute Not Supported by Firmware",
+			fmt.Sprintf("The %s attribute requires %s or later (XML API version %s), but the Sophos Firewall reports API version %s. "+
+				"Remove the attribute or upgrade the firmware.", feature.attribute.String(), feature.firmware, feature.minAPIVersion, apiVersion),
+		)
+	}
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// nameRegexAttribute is the name filter shared by the list data sources
+var nameRegexAttribute = schema.StringAttribute{
+	Description: "Regular expression the names must match (RE2 syntax, unanchored)",
+	Optional:    true,
+}
+
+// filterAttribute returns an optional filter attribute matching the value
+// of an object attribute
+func filterAttribute(description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: description + ", compared case-insensitively",
+		Optional:    true,
+	}
+}
+
+// compileNameRegex compiles the name_regex filter. Without a filter every
+// name matches.
+func compileNameRegex(filter types.String, diags *diag.Diagnostics) *regexp.Regexp {
+	if filter.IsNull() || filter.ValueString() == "" {
+		return nil
+	}
+	re, err := regexp.Compile(filter.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("name_regex"), "Invalid Name Regular Expression", err.Error())
+		return nil
+	}
+	return re
+}
+
+// matchName reports whether the name matches the compiled name_regex filter
+func matchName(re *regexp.Regexp, name string) bool {
+	return re == nil || re.MatchString(name)
+}
+
+// matchFilter reports whether the value matches an optional filter
+func matchFilter(filter types.String, value string) bool {
+	return filter.IsNull() || filter.ValueString() == "" || strings.EqualFold(filter.ValueString(), value)
+}
+
+// matchBool reports whether the value matches an optional boolean filter
+func matchBool(filter types.Bool, value types.Bool) bool {
+	return filter.IsNull() || filter.Equal(value)
+}
+
+// matchMember reports whether one of the values matches an optional
+// membership filter
+func matchMember(filter types.String, values ...[]types.String) bool {
+	if filter.IsNull() || filter.ValueString() == "" {
+		return true
+	}
+	for _, list := range values {
+		if slices.ContainsFunc(list, func(value types.String) bool {
+			return strings.EqualFold(filter.ValueString(), value.ValueString())
+		}) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/common"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/iphostgroup"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/machost"
+)
+
+// newReplayClient returns a client answered from a fixture in
+// testdata/fixtures, recorded by running the provider with
+// SOPHOS_RECORD_FILE set. The test fails if a recorded request is not sent.
+func newReplayClient(t *testing.T, fixture string) *common.BaseClient {
+	t.Helper()
+	replay, err := common.LoadFixture("testdata/fixtures/" + fixture)
+	if err != nil {
+		t.Fatalf("load fixture: %v", err)
+	}
+	client, err := common.NewBaseClient(common.Config{
+		Endpoint: "https://firewall.example",
+		Username: "test",
+		Password: "test",
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	client.Client.Transport = replay
+	t.Cleanup(func() {
+		if unused := replay.Unused(); len(unused) != 0 {
+			t.Errorf("requests in %s were not sent: %v", fixture, unused)
+		}
+	})
+	return client
+}
+
+func TestFixtureMACHostList(t *testing.T) {
+	client := machost.NewClient(newReplayClient(t, "machost_maclist.jsonl"))
+
+	host, err := client.ReadMACHost(context.Background(), "printers")
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if host == nil {
+		t.Fatal("MAC host not found")
+	}
+	want := []string{"00:16:3e:00:00:01", "00:16:3e:00:00:02"}
+	if host.Type != "MACLIST" || !reflect.DeepEqual(host.ListOfMACAddresses, want) {
+		t.Errorf("got type %q with %v, want MACLIST with %v", host.Type, host.ListOfMACAddresses, want)
+	}
+}
+
+func TestFixtureIPHostGroupHostList(t *testing.T) {
+	client := iphostgroup.NewClient(newReplayClient(t, "iphostgroup_hostlist.jsonl"))
+
+	group, err := client.ReadIPHostGroup(context.Background(), "web_servers")
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if group == nil {
+		t.Fatal("IP host group not found")
+	}
+	hosts := append([]string(nil), group.HostList.Hosts...)
+	sort.Strings(hosts)
+	if want := []string{"web1", "web2"}; !reflect.DeepEqual(hosts, want) {
+		t.Errorf("got hosts %v, want %v", hosts, want)
+	}
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// stringSet converts names to the elements of a set attribute. Duplicates
+// are dropped, as a set cannot hold them.
+func stringSet(values []string) []types.String {
+	set := make([]types.String, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		if seen[value] {
+			continue
+		}
+		seen[value] = true
+		set = append(set, types.StringValue(value))
+	}
+	return set
+}
+
+// setStrings returns the names in a set attribute
+func setStrings(set []types.String) []string {
+	values := make([]string, 0, len(set))
+	for _, value := range set {
+		values = append(values, value.ValueString())
+	}
+	return values
+}
+
+// sortedList converts names to the elements of a list attribute. The names
+// are sorted, as the appliance does not keep their order.
+func sortedList(values []string) []types.String {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	list := make([]types.String, 0, len(sorted))
+	for _, value := range sorted {
+		list = append(list, types.StringValue(value))
+	}
+	return list
+}
+
+// priorOrder returns the names read for a list attribute in the order of its
+// prior value when both hold the same names, so the order of the
+// configuration is kept. A null prior value stays null while no names are
+// read.
+func priorOrder(prior, read []types.String) []types.String {
+	if prior == nil && len(read) == 0 {
+		return nil
+	}
+	priorNames, readNames := setStrings(prior), setStrings(read)
+	slices.Sort(priorNames)
+	slices.Sort(readNames)
+	if !slices.Equal(priorNames, readNames) {
+		return read
+	}
+	return prior
+}
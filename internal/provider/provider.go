@@ -3,12 +3,25 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/common"
+)
+
+const (
+	// defaultRequestTimeout limits each API request unless request_timeout is set
+	defaultRequestTimeout = time.Minute
+	// defaultTimeout limits resource operations without a timeouts block entry
+	defaultTimeout = 5 * time.Minute
 )
 
 // Ensure the implementation satisfies the expected interfaces
@@ -23,6 +36,31 @@ type SophosProviderModel struct {
 	Username types.String `tfsdk:"username"`
 	Password types.String `tfsdk:"password"`
 	Insecure types.Bool   `tfsdk:"insecure"`
+	Port     types.Int64  `tfsdk:"port"`
+	APIPath  types.String `tfsdk:"api_path"`
+	ProxyURL types.String `tfsdk:"proxy_url"`
+
+	PasswordFile    types.String `tfsdk:"password_file"`
+	PasswordCommand types.List   `tfsdk:"password_command"`
+
+	CACertPEM       types.String `tfsdk:"ca_cert_pem"`
+	CACertFile      types.String `tfsdk:"ca_cert_file"`
+	TLSServerName   types.String `tfsdk:"tls_server_name"`
+	CertFingerprint types.String `tfsdk:"cert_fingerprint_sha256"`
+	MinTLSVersion   types.String `tfsdk:"min_tls_version"`
+
+	RequestTimeout types.String `tfsdk:"request_timeout"`
+	MaxRetries     types.Int64  `tfsdk:"max_retries"`
+	RetryWaitMin   types.String `tfsdk:"retry_wait_min"`
+	RetryWaitMax   types.String `tfsdk:"retry_wait_max"`
+
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+
+	BatchWindow  types.String `tfsdk:"batch_window"`
+	MaxBatchSize types.Int64  `tfsdk:"max_batch_size"`
+
+	ReadCache types.Bool `tfsdk:"read_cache"`
 }
 
 func New() provider.Provider {
@@ -40,26 +78,107 @@ func (p *SophosProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 		Description: "Interact with Sophos Firewall XML API",
 		Attributes: map[string]schema.Attribute{
 			"endpoint": schema.StringAttribute{
-				Description: "The endpoint URL of the Sophos Firewall API",
-				Required:    true,
+				Description: "The endpoint URL of the Sophos Firewall API. Can also be set with the SOPHOS_ENDPOINT environment variable.",
+				Optional:    true,
 			},
 			"username": schema.StringAttribute{
-				Description: "Username for API authentication",
-				Required:    true,
+				Description: "Username for API authentication. Can also be set with the SOPHOS_USERNAME environment variable.",
+				Optional:    true,
 			},
 			"password": schema.StringAttribute{
-				Description: "Password for API authentication",
-				Required:    true,
+				Description: "Password for API authentication. Can also be set with the SOPHOS_PASSWORD environment variable.",
+				Optional:    true,
 				Sensitive:   true,
 			},
+			"port": schema.Int64Attribute{
+				Description: "Port of the webadmin console when the endpoint does not include it, e.g. 4444.",
+				Optional:    true,
+			},
+			"api_path": schema.StringAttribute{
+				Description: "Path of the XML API controller, appended to the endpoint. Defaults to \"/webconsole/APIController\".",
+				Optional:    true,
+			},
+			"proxy_url": schema.StringAttribute{
+				Description: "URL of an HTTP, HTTPS or SOCKS5 proxy used to reach the appliance. Defaults to the HTTPS_PROXY environment variable. NO_PROXY is honored.",
+				Optional:    true,
+			},
+			"password_file": schema.StringAttribute{
+				Description: "Path of a file containing the password. A trailing newline is ignored. Conflicts with password and password_command.",
+				Optional:    true,
+			},
+			"password_command": schema.ListAttribute{
+				Description: "Command printing the password on standard output, e.g. a secrets manager CLI, given as the program followed by its arguments. It is run without a shell. Conflicts with password and password_file.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
 			"insecure": schema.BoolAttribute{
 				Description: "Skip TLS certificate verification",
 				Optional:    true,
 			},
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "PEM encoded CA certificates used instead of the system roots to verify the appliance certificate. Conflicts with ca_cert_file.",
+				Optional:    true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "Path of a PEM file with CA certificates used instead of the system roots to verify the appliance certificate. Conflicts with ca_cert_pem.",
+				Optional:    true,
+			},
+			"tls_server_name": schema.StringAttribute{
+				Description: "Name expected in the appliance certificate when it differs from the endpoint host, e.g. when connecting by IP address.",
+				Optional:    true,
+			},
+			"cert_fingerprint_sha256": schema.StringAttribute{
+				Description: "SHA-256 fingerprint of the appliance certificate, as hex optionally separated by colons. Connections presenting another certificate are refused. Combined with insecure, trusts a self-signed certificate by its fingerprint alone.",
+				Optional:    true,
+			},
+			"min_tls_version": schema.StringAttribute{
+				Description: "Minimum TLS version, one of \"1.0\", \"1.1\", \"1.2\" or \"1.3\". Defaults to \"1.2\".",
+				Optional:    true,
+			},
+			"request_timeout": schema.StringAttribute{
+				Description: "Timeout for each request to the XML API as a duration, e.g. \"30s\" or \"2m\". Defaults to \"1m\".",
+				Optional:    true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "Number of times a request is retried when the appliance is temporarily unavailable. Defaults to 3, 0 disables retries.",
+				Optional:    true,
+			},
+			"retry_wait_min": schema.StringAttribute{
+				Description: "Wait before the first retry as a duration. Doubles with every retry. Defaults to \"1s\".",
+				Optional:    true,
+			},
+			"retry_wait_max": schema.StringAttribute{
+				Description: "Maximum wait between retries as a duration. Defaults to \"30s\".",
+				Optional:    true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "Maximum number of requests sent to the XML API at the same time. Defaults to 4, 0 disables the limit.",
+				Optional:    true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Description: "Average number of requests per second sent to the XML API. Not limited by default.",
+				Optional:    true,
+			},
+			"batch_window": schema.StringAttribute{
+				Description: "Enables batching: creates and updates of the same object type that start within this duration, e.g. \"200ms\", are sent in one request. Disabled by default.",
+				Optional:    true,
+			},
+			"max_batch_size": schema.Int64Attribute{
+				Description: "Maximum number of objects sent in one batched request. Defaults to 50.",
+				Optional:    true,
+			},
+			"read_cache": schema.BoolAttribute{
+				Description: "Reads each object table from the firewall once per run and serves refreshes from memory. Creates, updates and deletes clear the cached table of the written type. Disabled by default.",
+				Optional:    true,
+			},
 		},
 	}
 }
 
+// envRecordFile enables record mode, see common.Config.RecordFile. It is an
+// environment variable only, as fixtures are captured for development.
+const envRecordFile = "SOPHOS_RECORD_FILE"
+
 // Configure prepares a Sophos API client for data sources and resources
 func (p *SophosProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	var config SophosProviderModel
@@ -69,30 +188,145 @@ func (p *SophosProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
+	endpoint := stringOrEnv(&resp.Diagnostics, "endpoint", config.Endpoint, envEndpoint)
+	username := stringOrEnv(&resp.Diagnostics, "username", config.Username, envUsername)
+	password := resolvePassword(ctx, &resp.Diagnostics, config)
+
 	insecure := false
 	if !config.Insecure.IsNull() {
 		insecure = config.Insecure.ValueBool()
 	}
 
+	requestTimeout := parseDuration(&resp.Diagnostics, "request_timeout", config.RequestTimeout, defaultRequestTimeout)
+	retryWaitMin := parseDuration(&resp.Diagnostics, "retry_wait_min", config.RetryWaitMin, common.DefaultRetryWaitMin)
+	retryWaitMax := parseDuration(&resp.Diagnostics, "retry_wait_max", config.RetryWaitMax, common.DefaultRetryWaitMax)
+
+	maxRetries := common.DefaultMaxRetries
+	if !config.MaxRetries.IsNull() {
+		maxRetries = int(config.MaxRetries.ValueInt64())
+		if maxRetries < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid Max Retries",
+				"The max_retries must not be negative.",
+			)
+		}
+	}
+	maxConcurrentRequests := common.DefaultMaxConcurrentRequests
+	if !config.MaxConcurrentRequests.IsNull() {
+		maxConcurrentRequests = int(config.MaxConcurrentRequests.ValueInt64())
+		if maxConcurrentRequests < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_concurrent_requests"),
+				"Invalid Max Concurrent Requests",
+				"The max_concurrent_requests must not be negative.",
+			)
+		}
+	}
+	batchWindow := parseDuration(&resp.Diagnostics, "batch_window", config.BatchWindow, 0)
+	maxBatchSize := common.DefaultMaxBatchSize
+	if !config.MaxBatchSize.IsNull() {
+		maxBatchSize = int(config.MaxBatchSize.ValueInt64())
+		if maxBatchSize < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_batch_size"),
+				"Invalid Max Batch Size",
+				"The max_batch_size must be at least 1.",
+			)
+		}
+	}
+	requestsPerSecond := config.RequestsPerSecond.ValueFloat64()
+	if requestsPerSecond < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("requests_per_second"),
+			"Invalid Requests Per Second",
+			"The requests_per_second must not be negative.",
+		)
+	}
+	if retryWaitMin > retryWaitMax {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_wait_min"),
+			"Invalid Retry Wait",
+			"The retry_wait_min must not be greater than retry_wait_max.",
+		)
+	}
 	// Create a Sophos client using the configuration
-	client := NewSophosClient(
-		config.Endpoint.ValueString(),
-		config.Username.ValueString(),
-		config.Password.ValueString(),
-		insecure,
-	)
+	clientConfig := common.Config{
+		Endpoint:       endpoint,
+		Username:       username,
+		Password:       password,
+		Insecure:       insecure,
+		Port:           int(config.Port.ValueInt64()),
+		APIPath:        config.APIPath.ValueString(),
+		ProxyURL:       config.ProxyURL.ValueString(),
+		RequestTimeout: requestTimeout,
+		MaxRetries:     maxRetries,
+		RetryWaitMin:   retryWaitMin,
+		RetryWaitMax:   retryWaitMax,
+
+		MaxConcurrentRequests: maxConcurrentRequests,
+		RequestsPerSecond:     requestsPerSecond,
+
+		BatchWindow:  batchWindow,
+		MaxBatchSize: maxBatchSize,
+
+		ReadCache: config.ReadCache.ValueBool(),
+
+		RecordFile: os.Getenv(envRecordFile),
+	}
+	applyTLSConfig(&resp.Diagnostics, config, &clientConfig)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := NewSophosClient(clientConfig)
+	if err != nil {
+		addClientConfigError(&resp.Diagnostics, config, err)
+		return
+	}
+
+	// A single login up front reports bad credentials or an unreachable
+	// appliance once instead of on every resource
+	if _, err := client.Login(ctx); err != nil {
+		addLoginError(&resp.Diagnostics, client.URL, err)
+		return
+	}
 
 	resp.ResourceData = client
 	resp.DataSourceData = client
 }
 
+// parseDuration returns the duration set in a provider attribute, or
+// fallback when it is not set
+func parseDuration(diags *diag.Diagnostics, attribute string, value types.String, fallback time.Duration) time.Duration {
+	if value.IsNull() {
+		return fallback
+	}
+	duration, err := time.ParseDuration(value.ValueString())
+	if err != nil || duration <= 0 {
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Invalid Duration",
+			fmt.Sprintf("The %s must be a positive duration such as \"30s\" or \"2m\", got: %s", attribute, value.ValueString()),
+		)
+		return fallback
+	}
+	return duration
+}
+
 // Resources defines the resources implemented in the provider
 func (p *SophosProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewIPHostResource,
 		NewIPHostGroupResource,
+		NewFQDNHostResource,
+		NewFQDNHostGroupResource,
 		NewMACHostResource,
+		NewMACHostGroupResource,
 		NewFirewallRuleResource,
+		NewFirewallRuleOrderResource,
+		NewServiceResource,
+		NewServiceGroupResource,
 	}
 }
 
@@ -100,6 +334,19 @@ func (p *SophosProvider) Resources(_ context.Context) []func() resource.Resource
 func (p *SophosProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewIPHostDataSource,
+		NewIPHostGroupDataSource,
+		NewFQDNHostDataSource,
+		NewFQDNHostGroupDataSource,
+		NewMACHostDataSource,
+		NewMACHostGroupDataSource,
+		NewFirewallRuleDataSource,
+		NewSystemInfoDataSource,
+		NewIPHostsDataSource,
+		NewIPHostGroupsDataSource,
+		NewMACHostsDataSource,
+		NewFirewallRulesDataSource,
+		NewServiceDataSource,
+		NewServiceGroupDataSource,
 	}
 }
 
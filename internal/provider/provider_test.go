@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/testserver"
+)
+
+// testAccProtoV6ProviderFactories serves the provider to the Terraform CLI in
+// acceptance tests
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"sophosfirewall": providerserver.NewProtocol6WithError(New()),
+}
+
+// testAccServer starts an in-memory XML API for one test and returns it
+// with a provider block pointing at it
+func testAccServer(t *testing.T) (*testserver.Server, string) {
+	t.Helper()
+	server := testserver.New()
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+
+	config := fmt.Sprintf(`
+provider "sophosfirewall" {
+  endpoint = %q
+  username = %q
+  password = %q
+}
+`, ts.URL, testserver.DefaultUsername, testserver.DefaultPassword)
+	return server, config
+}
+
+// testAccCheckEntityExists verifies the server stores the entity
+func testAccCheckEntityExists(server *testserver.Server, entityType, name string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		if _, ok := server.Entity(entityType, name); !ok {
+			return fmt.Errorf("%s %q not found on the server", entityType, name)
+		}
+		return nil
+	}
+}
+
+// testAccCheckEntityDestroyed verifies the server no longer stores the entity
+func testAccCheckEntityDestroyed(server *testserver.Server, entityType, name string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		if _, ok := server.Entity(entityType, name); ok {
+			return fmt.Errorf("%s %q still exists on the server", entityType, name)
+		}
+		return nil
+	}
+}
+
+// roundTripRuns is the number of random models a round-trip property test
+// converts to the API structure and back
+const roundTripRuns = 500
+
+// randomRunes are the characters of random attribute values, including
+// those that need escaping in XML
+var randomRunes = []rune("abcXYZ019 -_.:/,<>&\"'\t\nü✓")
+
+// randomText returns a random attribute value, possibly empty
+func randomText(r *rand.Rand) string {
+	var text strings.Builder
+	for n := r.Intn(12); n > 0; n-- {
+		text.WriteRune(randomRunes[r.Intn(len(randomRunes))])
+	}
+	return text.String()
+}
+
+// randomName returns a random non-empty value
+func randomName(r *rand.Rand) string {
+	return fmt.Sprintf("%s%d", randomText(r), r.Intn(1000))
+}
+
+// randomOptional returns null or a random non-empty value
+func randomOptional(r *rand.Rand) types.String {
+	if r.Intn(2) == 0 {
+		return types.StringNull()
+	}
+	return types.StringValue(randomName(r))
+}
+
+// randomList returns nil or up to three random values
+func randomList(r *rand.Rand) []types.String {
+	n := r.Intn(4)
+	if n == 0 {
+		return nil
+	}
+	list := make([]types.String, n)
+	for i := range list {
+		list[i] = types.StringValue(randomName(r))
+	}
+	return list
+}
+
+func TestAccProviderLoginFailure(t *testing.T) {
+	server, providerConfig := testAccServer(t)
+	server.SetCredentials("admin", "other")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      providerConfig + `data "sophosfirewall_system_info" "test" {}`,
+				ExpectError: regexp.MustCompile(`Sophos Firewall Authentication Failed`),
+			},
+		},
+	})
+}
+
+func TestAccProviderAPIAccessDenied(t *testing.T) {
+	server, providerConfig := testAccServer(t)
+	server.SetAPIAccess(false)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      providerConfig + `data "sophosfirewall_system_info" "test" {}`,
+				ExpectError: regexp.MustCompile(`Sophos Firewall API Access Not Allowed`),
+			},
+		},
+	})
+}
@@ -10,6 +10,7 @@ import (
 	// "bytes"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -104,55 +105,93 @@ type firewallRuleSetXML struct {
 // Ensure the implementation satisfies the expected interfaces
 var _ resource.Resource = &firewallRuleResource{}
 var _ resource.ResourceWithImportState = &firewallRuleResource{}
+var _ resource.ResourceWithModifyPlan = &firewallRuleResource{}
+var _ resource.ResourceWithValidateConfig = &firewallRuleResource{}
+var _ resource.ResourceWithUpgradeState = &firewallRuleResource{}
 
 // firewallRuleResource is the resource implementation
 type firewallRuleResource struct {
 	client *firewallrule.Client
 }
 
-// Updated resource model with all available fields
-type firewallRuleResourceModel struct {
-	Name                          types.String   `tfsdk:"name"`
-	Description                   types.String   `tfsdk:"description"`
-	IPFamily                      types.String   `tfsdk:"ip_family"`
-	Status                        types.String   `tfsdk:"status"`
-	Position                      types.String   `tfsdk:"position"`
-	PolicyType                    types.String   `tfsdk:"policy_type"`
-	AfterRule                     types.String   `tfsdk:"after_rule"`
-	BeforeRule                    types.String   `tfsdk:"before_rule"`
+// firewallRuleModel maps the firewall rule attributes shared by the resource and data source
+type firewallRuleModel struct {
+	Name          types.String        `tfsdk:"name"`
+	Description   types.String        `tfsdk:"description"`
+	IPFamily      types.String        `tfsdk:"ip_family"`
+	Enabled       types.Bool          `tfsdk:"enabled"`
+	Position      types.String        `tfsdk:"position"`
+	PolicyType    types.String        `tfsdk:"policy_type"`
+	AfterRule     types.String        `tfsdk:"after_rule"`
+	BeforeRule    types.String        `tfsdk:"before_rule"`
+	NetworkPolicy *networkPolicyModel `tfsdk:"network_policy"`
+	UserPolicy    *userPolicyModel    `tfsdk:"user_policy"`
+}
+
+// networkPolicyModel maps the network_policy block. Zones, networks and
+// services are sets, as the appliance does not keep their order.
+type networkPolicyModel struct {
 	Action                        types.String   `tfsdk:"action"`
-	LogTraffic                    types.String   `tfsdk:"log_traffic"`
-	SkipLocalDestined             types.String   `tfsdk:"skip_local_destined"`
+	LogTraffic                    types.Bool     `tfsdk:"log_traffic"`
+	SkipLocalDestined             types.Bool     `tfsdk:"skip_local_destined"`
+	Schedule                      types.String   `tfsdk:"schedule"`
 	SourceZones                   []types.String `tfsdk:"source_zones"`
 	DestinationZones              []types.String `tfsdk:"destination_zones"`
-	Schedule                      types.String   `tfsdk:"schedule"`
 	SourceNetworks                []types.String `tfsdk:"source_networks"`
 	DestinationNetworks           []types.String `tfsdk:"destination_networks"`
+	Services                      []types.String `tfsdk:"services"`
 	DSCPMarking                   types.String   `tfsdk:"dscp_marking"`
 	WebFilter                     types.String   `tfsdk:"web_filter"`
 	WebCategoryBaseQoSPolicy      types.String   `tfsdk:"web_category_base_qos_policy"`
-	BlockQuickQuic                types.String   `tfsdk:"block_quick_quic"`
-	ScanVirus                     types.String   `tfsdk:"scan_virus"`
-	ZeroDayProtection             types.String   `tfsdk:"zero_day_protection"`
-	ProxyMode                     types.String   `tfsdk:"proxy_mode"`
-	DecryptHTTPS                  types.String   `tfsdk:"decrypt_https"`
+	BlockQuickQuic                types.Bool     `tfsdk:"block_quick_quic"`
+	ScanVirus                     types.Bool     `tfsdk:"scan_virus"`
+	ZeroDayProtection             types.Bool     `tfsdk:"zero_day_protection"`
+	ProxyMode                     types.Bool     `tfsdk:"proxy_mode"`
+	DecryptHTTPS                  types.Bool     `tfsdk:"decrypt_https"`
 	ApplicationControl            types.String   `tfsdk:"application_control"`
 	ApplicationBaseQoSPolicy      types.String   `tfsdk:"application_base_qos_policy"`
 	IntrusionPrevention           types.String   `tfsdk:"intrusion_prevention"`
-	TrafficShappingPolicy         types.String   `tfsdk:"traffic_shapping_policy"`
-	ScanSMTP                      types.String   `tfsdk:"scan_smtp"`
-	ScanSMTPS                     types.String   `tfsdk:"scan_smtps"`
-	ScanIMAP                      types.String   `tfsdk:"scan_imap"`
-	ScanIMAPS                     types.String   `tfsdk:"scan_imaps"`
-	ScanPOP3                      types.String   `tfsdk:"scan_pop3"`
-	ScanPOP3S                     types.String   `tfsdk:"scan_pop3s"`
-	ScanFTP                       types.String   `tfsdk:"scan_ftp"`
-	SourceSecurityHeartbeat       types.String   `tfsdk:"source_security_heartbeat"`
+	TrafficShapingPolicy          types.String   `tfsdk:"traffic_shaping_policy"`
+	ScanSMTP                      types.Bool     `tfsdk:"scan_smtp"`
+	ScanSMTPS                     types.Bool     `tfsdk:"scan_smtps"`
+	ScanIMAP                      types.Bool     `tfsdk:"scan_imap"`
+	ScanIMAPS                     types.Bool     `tfsdk:"scan_imaps"`
+	ScanPOP3                      types.Bool     `tfsdk:"scan_pop3"`
+	ScanPOP3S                     types.Bool     `tfsdk:"scan_pop3s"`
+	ScanFTP                       types.Bool     `tfsdk:"scan_ftp"`
+	SourceSecurityHeartbeat       types.Bool     `tfsdk:"source_security_heartbeat"`
 	MinimumSourceHBPermitted      types.String   `tfsdk:"minimum_source_hb_permitted"`
-	DestSecurityHeartbeat         types.String   `tfsdk:"dest_security_heartbeat"`
+	DestSecurityHeartbeat         types.Bool     `tfsdk:"dest_security_heartbeat"`
 	MinimumDestinationHBPermitted types.String   `tfsdk:"minimum_destination_hb_permitted"`
 }
 
+// userPolicyModel maps the user_policy block of identity-based rules
+type userPolicyModel struct {
+	networkPolicyModel
+	Identity          []types.String `tfsdk:"identity"`
+	MatchIdentity     types.Bool     `tfsdk:"match_identity"`
+	ShowCaptivePortal types.Bool     `tfsdk:"show_captive_portal"`
+	DataAccounting    types.Bool     `tfsdk:"data_accounting"`
+}
+
+// policy returns the network policy settings of the rule, which user rules
+// keep in their user policy
+func (m firewallRuleModel) policy() *networkPolicyModel {
+	switch {
+	case m.NetworkPolicy != nil:
+		return m.NetworkPolicy
+	case m.UserPolicy != nil:
+		return &m.UserPolicy.networkPolicyModel
+	}
+	return &networkPolicyModel{}
+}
+
+// firewallRuleResourceModel maps the resource schema data
+type firewallRuleResourceModel struct {
+	firewallRuleModel
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
 // NewFirewallRuleResource creates a new resource
 func NewFirewallRuleResource() resource.Resource {
 	return &firewallRuleResource{}
@@ -164,9 +203,10 @@ func (r *firewallRuleResource) Metadata(_ context.Context, req resource.Metadata
 }
 
 // Schema defines the schema for the resource with all available fields
-func (r *firewallRuleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *firewallRuleResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a Sophos Firewall rule",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				Description: "Name of the firewall rule",
@@ -184,8 +224,8 @@ func (r *firewallRuleResource) Schema(_ context.Context, _ resource.SchemaReques
 				Optional:    true,
 				Computed:    true,
 			},
-			"status": schema.StringAttribute{
-				Description: "Status (Enable or Disable)",
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the rule is enabled",
 				Optional:    true,
 				Computed:    true,
 			},
@@ -195,7 +235,7 @@ func (r *firewallRuleResource) Schema(_ context.Context, _ resource.SchemaReques
 				Computed:    true,
 			},
 			"policy_type": schema.StringAttribute{
-				Description: "Policy Type (Network)",
+				Description: "Policy Type (Network or User)",
 				Required:    true,
 			},
 			"after_rule": schema.StringAttribute{
@@ -206,160 +246,226 @@ func (r *firewallRuleResource) Schema(_ context.Context, _ resource.SchemaReques
 				Description: "Rule to position before (used when position is 'Before')",
 				Optional:    true,
 			},
-			"action": schema.StringAttribute{
-				Description: "Action (Accept, Reject, Drop)",
-				Required:    true,
-			},
-			"log_traffic": schema.StringAttribute{
-				Description: "Log traffic (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"skip_local_destined": schema.StringAttribute{
-				Description: "Skip local destined (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"source_zones": schema.ListAttribute{
-				Description: "List of source zones",
-				Required:    true,
-				ElementType: types.StringType,
-			},
-			"destination_zones": schema.ListAttribute{
-				Description: "List of destination zones",
-				Required:    true,
-				ElementType: types.StringType,
-			},
-			"schedule": schema.StringAttribute{
-				Description: "Schedule name",
-				Optional:    true,
-				Computed:    true,
-			},
-			"source_networks": schema.ListAttribute{
-				Description: "List of source networks",
-				Optional:    true,
-				ElementType: types.StringType,
-			},
-			"destination_networks": schema.ListAttribute{
-				Description: "List of destination networks",
-				Optional:    true,
-				ElementType: types.StringType,
-			},
-			"dscp_marking": schema.StringAttribute{
-				Description: "DSCP Marking value",
-				Optional:    true,
-				Computed:    true,
-			},
-			"web_filter": schema.StringAttribute{
-				Description: "Web Filter policy",
-				Optional:    true,
-				Computed:    true,
-			},
-			"web_category_base_qos_policy": schema.StringAttribute{
-				Description: "Web Category Base QoS Policy",
-				Optional:    true,
-				Computed:    true,
-			},
-			"block_quick_quic": schema.StringAttribute{
-				Description: "Block Quick/QUIC protocol (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"scan_virus": schema.StringAttribute{
-				Description: "Scan for viruses (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"zero_day_protection": schema.StringAttribute{
-				Description: "Zero Day Protection (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"proxy_mode": schema.StringAttribute{
-				Description: "Proxy Mode (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"decrypt_https": schema.StringAttribute{
-				Description: "Decrypt HTTPS (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"application_control": schema.StringAttribute{
-				Description: "Application Control policy",
-				Optional:    true,
-				Computed:    true,
-			},
-			"application_base_qos_policy": schema.StringAttribute{
-				Description: "Application Base QoS Policy",
-				Optional:    true,
-				Computed:    true,
-			},
-			"intrusion_prevention": schema.StringAttribute{
-				Description: "Intrusion Prevention policy",
-				Optional:    true,
-				Computed:    true,
-			},
-			"traffic_shapping_policy": schema.StringAttribute{
-				Description: "Traffic Shaping Policy",
-				Optional:    true,
-				Computed:    true,
-			},
-			"scan_smtp": schema.StringAttribute{
-				Description: "Scan SMTP (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"scan_smtps": schema.StringAttribute{
-				Description: "Scan SMTPS (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"scan_imap": schema.StringAttribute{
-				Description: "Scan IMAP (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"scan_imaps": schema.StringAttribute{
-				Description: "Scan IMAPS (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"scan_pop3": schema.StringAttribute{
-				Description: "Scan POP3 (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"scan_pop3s": schema.StringAttribute{
-				Description: "Scan POP3S (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"scan_ftp": schema.StringAttribute{
-				Description: "Scan FTP (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"source_security_heartbeat": schema.StringAttribute{
-				Description: "Source Security Heartbeat (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"minimum_source_hb_permitted": schema.StringAttribute{
-				Description: "Minimum Source HB Permitted",
-				Optional:    true,
-				Computed:    true,
-			},
-			"dest_security_heartbeat": schema.StringAttribute{
-				Description: "Destination Security Heartbeat (Enable or Disable)",
-				Optional:    true,
-				Computed:    true,
-			},
-			"minimum_destination_hb_permitted": schema.StringAttribute{
-				Description: "Minimum Destination HB Permitted",
-				Optional:    true,
-				Computed:    true,
-			},
+		},
+		Blocks: map[string]schema.Block{
+			"network_policy": schema.SingleNestedBlock{
+				Description: "Network policy of the rule, required when policy_type is Network",
+				Attributes:  policyAttributes(),
+			},
+			"user_policy": schema.SingleNestedBlock{
+				Description: "User policy of the rule, required when policy_type is User",
+				Attributes:  userPolicyAttributes(),
+			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// policyAttributes returns the attributes of the network_policy block. The
+// user_policy block has them as well. Action and zones are required in the
+// block of the policy type, see ValidateConfig.
+func policyAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"action": schema.StringAttribute{
+			Description: "Action (Accept, Reject, Drop)",
+			Optional:    true,
+		},
+		"log_traffic": schema.BoolAttribute{
+			Description: "Log traffic",
+			Optional:    true,
+			Computed:    true,
+		},
+		"skip_local_destined": schema.BoolAttribute{
+			Description: "Skip local destined",
+			Optional:    true,
+			Computed:    true,
+		},
+		"schedule": schema.StringAttribute{
+			Description: "Schedule name",
+			Optional:    true,
+			Computed:    true,
+		},
+		"source_zones": schema.SetAttribute{
+			Description: "Set of source zones",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"destination_zones": schema.SetAttribute{
+			Description: "Set of destination zones",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"source_networks": schema.SetAttribute{
+			Description: "Set of source networks",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"destination_networks": schema.SetAttribute{
+			Description: "Set of destination networks",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"services": schema.SetAttribute{
+			Description: "Set of services",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"dscp_marking": schema.StringAttribute{
+			Description: "DSCP Marking value",
+			Optional:    true,
+			Computed:    true,
+		},
+		"web_filter": schema.StringAttribute{
+			Description: "Web Filter policy",
+			Optional:    true,
+			Computed:    true,
+		},
+		"web_category_base_qos_policy": schema.StringAttribute{
+			Description: "Web Category Base QoS Policy",
+			Optional:    true,
+			Computed:    true,
+		},
+		"block_quick_quic": schema.BoolAttribute{
+			Description: "Block Quick/QUIC protocol",
+			Optional:    true,
+			Computed:    true,
+		},
+		"scan_virus": schema.BoolAttribute{
+			Description: "Scan for viruses",
+			Optional:    true,
+			Computed:    true,
+		},
+		"zero_day_protection": schema.BoolAttribute{
+			Description: "Zero Day Protection",
+			Optional:    true,
+			Computed:    true,
+		},
+		"proxy_mode": schema.BoolAttribute{
+			Description: "Proxy Mode",
+			Optional:    true,
+			Computed:    true,
+		},
+		"decrypt_https": schema.BoolAttribute{
+			Description: "Decrypt HTTPS",
+			Optional:    true,
+			Computed:    true,
+		},
+		"application_control": schema.StringAttribute{
+			Description: "Application Control policy",
+			Optional:    true,
+			Computed:    true,
+		},
+		"application_base_qos_policy": schema.StringAttribute{
+			Description: "Application Base QoS Policy",
+			Optional:    true,
+			Computed:    true,
+		},
+		"intrusion_prevention": schema.StringAttribute{
+			Description: "Intrusion Prevention policy",
+			Optional:    true,
+			Computed:    true,
+		},
+		"traffic_shaping_policy": schema.StringAttribute{
+			Description: "Traffic Shaping Policy",
+			Optional:    true,
+			Computed:    true,
+		},
+		"scan_smtp": schema.BoolAttribute{
+			Description: "Scan SMTP",
+			Optional:    true,
+			Computed:    true,
+		},
+		"scan_smtps": schema.BoolAttribute{
+			Description: "Scan SMTPS",
+			Optional:    true,
+			Computed:    true,
+		},
+		"scan_imap": schema.BoolAttribute{
+			Description: "Scan IMAP",
+			Optional:    true,
+			Computed:    true,
+		},
+		"scan_imaps": schema.BoolAttribute{
+			Description: "Scan IMAPS",
+			Optional:    true,
+			Computed:    true,
+		},
+		"scan_pop3": schema.BoolAttribute{
+			Description: "Scan POP3",
+			Optional:    true,
+			Computed:    true,
+		},
+		"scan_pop3s": schema.BoolAttribute{
+			Description: "Scan POP3S",
+			Optional:    true,
+			Computed:    true,
+		},
+		"scan_ftp": schema.BoolAttribute{
+			Description: "Scan FTP",
+			Optional:    true,
+			Computed:    true,
+		},
+		"source_security_heartbeat": schema.BoolAttribute{
+			Description: "Source Security Heartbeat",
+			Optional:    true,
+			Computed:    true,
+		},
+		"minimum_source_hb_permitted": schema.StringAttribute{
+			Description: "Minimum Source HB Permitted",
+			Optional:    true,
+			Computed:    true,
+		},
+		"dest_security_heartbeat": schema.BoolAttribute{
+			Description: "Destination Security Heartbeat",
+			Optional:    true,
+			Computed:    true,
+		},
+		"minimum_destination_hb_permitted": schema.StringAttribute{
+			Description: "Minimum Destination HB Permitted",
+			Optional:    true,
+			Computed:    true,
+		},
+	}
+}
+
+// userPolicyAttributes returns the attributes of the user_policy block
+func userPolicyAttributes() map[string]schema.Attribute {
+	attributes := policyAttributes()
+	attributes["identity"] = schema.SetAttribute{
+		Description: "Set of users and groups the rule matches",
+		Optional:    true,
+		ElementType: types.StringType,
+	}
+	attributes["match_identity"] = schema.BoolAttribute{
+		Description: "Match known users only",
+		Optional:    true,
+		Computed:    true,
+	}
+	attributes["show_captive_portal"] = schema.BoolAttribute{
+		Description: "Show the captive portal to unknown users",
+		Optional:    true,
+		Computed:    true,
+	}
+	attributes["data_accounting"] = schema.BoolAttribute{
+		Description: "Account the traffic of the users against their data quota",
+		Optional:    true,
+		Computed:    true,
+	}
+	return attributes
+}
+
+// UpgradeState migrates state written with the flat schema version 0
+func (r *firewallRuleResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaV0 := firewallRuleSchemaV0(ctx)
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   &schemaV0,
+			StateUpgrader: upgradeFirewallRuleStateV0,
 		},
 	}
 }
@@ -382,6 +488,81 @@ func (r *firewallRuleResource) Configure(_ context.Context, req resource.Configu
 	r.client = firewallrule.NewClient(client.BaseClient)
 }
 
+// ModifyPlan rejects attributes the firmware of the appliance does not support
+func (r *firewallRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the rule is destroyed or the provider is not configured yet
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	checkFeatures(ctx, &resp.Diagnostics, req.Config, r.client.APIVersion(), firewallRuleFeatures)
+}
+
+// policyBlocks maps the policy types to the block holding their policy
+var policyBlocks = map[string]string{
+	firewallrule.PolicyTypeNetwork: "network_policy",
+	firewallrule.PolicyTypeUser:    "user_policy",
+}
+
+// ValidateConfig requires the policy block of the policy type, and no
+// other, with the attributes every rule needs
+func (r *firewallRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var policyType types.String
+	diags := req.Config.GetAttribute(ctx, path.Root("policy_type"), &policyType)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || policyType.IsNull() || policyType.IsUnknown() {
+		return
+	}
+
+	block, ok := policyBlocks[policyType.ValueString()]
+	if !ok {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("policy_type"),
+			"Invalid Policy Type",
+			fmt.Sprintf("Expected policy_type Network or User, got: %q", policyType.ValueString()),
+		)
+		return
+	}
+
+	for _, other := range policyBlocks {
+		if other == block {
+			continue
+		}
+		var policy types.Object
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(other), &policy)...)
+		if !policy.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(other),
+				"Unexpected Policy Block",
+				fmt.Sprintf("A rule with policy_type %s cannot have a %s block, use %s instead.", policyType.ValueString(), other, block),
+			)
+		}
+	}
+
+	var policy types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(block), &policy)...)
+	if policy.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(block),
+			"Missing Policy Block",
+			fmt.Sprintf("A rule with policy_type %s requires a %s block.", policyType.ValueString(), block),
+		)
+		return
+	}
+	required := []string{"action", "source_zones", "destination_zones"}
+	if block == "user_policy" {
+		required = append(required, "identity")
+	}
+	for _, name := range required {
+		if value, ok := policy.Attributes()[name]; ok && value.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(block).AtName(name),
+				"Missing Required Attribute",
+				fmt.Sprintf("The %s block requires the %s attribute.", block, name),
+			)
+		}
+	}
+}
+
 // Create creates a new firewall rule
 func (r *firewallRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan firewallRuleResourceModel
@@ -391,20 +572,28 @@ func (r *firewallRuleResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Convert the model to API structure
-	rule := r.modelToAPIFirewallRule(plan)
+	rule := modelToAPIFirewallRule(plan.firewallRuleModel)
 
 	// Create the firewall rule
-	err := r.client.CreateFirewallRule(rule)
+	err := r.client.CreateFirewallRule(ctx, rule)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating firewall rule", err.Error())
+		addClientError(&resp.Diagnostics, "Error creating firewall rule", err)
 		return
 	}
 
 	// Read the created rule to ensure state is up-to-date
-	createdRule, err := r.client.ReadFirewallRule(plan.Name.ValueString())
+	createdRule, err := r.client.ReadFirewallRule(ctx, plan.Name.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading created firewall rule", err.Error())
+		addClientError(&resp.Diagnostics, "Error reading created firewall rule", err)
 		return
 	}
 
@@ -414,7 +603,10 @@ func (r *firewallRuleResource) Create(ctx context.Context, req resource.CreateRe
 	}
 
 	// Update the state with the actual created rule
-	state := r.apiToModelFirewallRule(*createdRule)
+	state := firewallRuleResourceModel{
+		firewallRuleModel: apiToModelFirewallRule(*createdRule),
+		Timeouts:          plan.Timeouts,
+	}
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 }
@@ -428,10 +620,18 @@ func (r *firewallRuleResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Get the firewall rule from the API
-	rule, err := r.client.ReadFirewallRule(state.Name.ValueString())
+	rule, err := r.client.ReadFirewallRule(ctx, state.Name.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading firewall rule", err.Error())
+		addClientError(&resp.Diagnostics, "Error reading firewall rule", err)
 		return
 	}
 
@@ -442,8 +642,10 @@ func (r *firewallRuleResource) Read(ctx context.Context, req resource.ReadReques
 	}
 
 	// Update the Terraform state
-	state = r.apiToModelFirewallRule(*rule)
-	
+	placement := state.firewallRuleModel
+	state.firewallRuleModel = apiToModelFirewallRule(*rule)
+	keepPlacement(&state.firewallRuleModel, placement)
+
 	// Save the updated state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -451,27 +653,45 @@ func (r *firewallRuleResource) Read(ctx context.Context, req resource.ReadReques
 
 // Update updates the resource and sets the updated Terraform state
 func (r *firewallRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan firewallRuleResourceModel
+	var plan, prior firewallRuleResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &prior)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	// Convert the model to API structure
-	rule := r.modelToAPIFirewallRule(plan)
+	rule := modelToAPIFirewallRule(plan.firewallRuleModel)
+
+	// Leave the rule where it is unless its position changed, as it may
+	// have been moved since, e.g. by sophosfirewall_firewall_rule_order
+	placement := plan.firewallRuleModel
+	if !placementChanged(plan.firewallRuleModel, prior.firewallRuleModel) {
+		rule.Position, rule.After, rule.Before = "", nil, nil
+		placement = prior.firewallRuleModel
+	}
 
 	// Update the firewall rule
-	err := r.client.UpdateFirewallRule(rule)
+	err := r.client.UpdateFirewallRule(ctx, rule)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating firewall rule", err.Error())
+		addClientError(&resp.Diagnostics, "Error updating firewall rule", err)
 		return
 	}
 
 	// Read the updated rule to ensure state is up-to-date
-	updatedRule, err := r.client.ReadFirewallRule(plan.Name.ValueString())
+	updatedRule, err := r.client.ReadFirewallRule(ctx, plan.Name.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading updated firewall rule", err.Error())
+		addClientError(&resp.Diagnostics, "Error reading updated firewall rule", err)
 		return
 	}
 
@@ -481,11 +701,40 @@ func (r *firewallRuleResource) Update(ctx context.Context, req resource.UpdateRe
 	}
 
 	// Update the state with the actual updated rule
-	state := r.apiToModelFirewallRule(*updatedRule)
+	state := firewallRuleResourceModel{
+		firewallRuleModel: apiToModelFirewallRule(*updatedRule),
+		Timeouts:          plan.Timeouts,
+	}
+	keepPlacement(&state.firewallRuleModel, placement)
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
 
+// placementChanged reports whether the position, after_rule or before_rule
+// of the plan differ from the prior state. An unknown position is not
+// configured and does not move the rule.
+func placementChanged(plan, prior firewallRuleModel) bool {
+	if plan.Position.IsUnknown() {
+		return false
+	}
+	return !plan.Position.Equal(prior.Position) ||
+		!plan.AfterRule.Equal(prior.AfterRule) ||
+		!plan.BeforeRule.Equal(prior.BeforeRule)
+}
+
+// keepPlacement keeps the position, after_rule and before_rule of prior in
+// the model. They tell where the rule was placed, not where it is now, so
+// moving the rule later does not show up as a change. A model without a
+// known position, e.g. on import, keeps the placement read from the API.
+func keepPlacement(model *firewallRuleModel, prior firewallRuleModel) {
+	if prior.Position.IsNull() || prior.Position.IsUnknown() {
+		return
+	}
+	model.Position = prior.Position
+	model.AfterRule = prior.AfterRule
+	model.BeforeRule = prior.BeforeRule
+}
+
 // Delete deletes the resource and removes the Terraform state
 func (r *firewallRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state firewallRuleResourceModel
@@ -495,10 +744,19 @@ func (r *firewallRuleResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Delete the firewall rule
-	err := r.client.DeleteFirewallRule(state.Name.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error deleting firewall rule", err.Error())
+	err := r.client.DeleteFirewallRule(ctx, state.Name.ValueString())
+	// An object that is already gone counts as deleted
+	if err != nil && !isNotFound(err) {
+		addClientError(&resp.Diagnostics, "Error deleting firewall rule", err)
 		return
 	}
 }
@@ -510,14 +768,14 @@ func (r *firewallRuleResource) ImportState(ctx context.Context, req resource.Imp
 }
 
 // Helper method to convert from Terraform model to API structure
-func (r *firewallRuleResource) modelToAPIFirewallRule(model firewallRuleResourceModel) *firewallrule.FirewallRule {
+func modelToAPIFirewallRule(model firewallRuleModel) *firewallrule.FirewallRule {
 	rule := &firewallrule.FirewallRule{
-		Name:         model.Name.ValueString(),
-		Description:  model.Description.ValueString(),
-		IPFamily:     model.IPFamily.ValueString(),
-		Status:       model.Status.ValueString(),
-		Position:     model.Position.ValueString(),
-		PolicyType:   model.PolicyType.ValueString(),
+		Name:          model.Name.ValueString(),
+		Description:   model.Description.ValueString(),
+		IPFamily:      model.IPFamily.ValueString(),
+		Status:        boolEnable(model.Enabled),
+		Position:      model.Position.ValueString(),
+		PolicyType:    model.PolicyType.ValueString(),
 		TransactionID: "",
 	}
 
@@ -534,280 +792,198 @@ func (r *firewallRuleResource) modelToAPIFirewallRule(model firewallRuleResource
 		}
 	}
 
-	// Set Network Policy
-	rule.NetworkPolicy = &firewallrule.NetworkPolicy{
-		Action:            model.Action.ValueString(),
-		LogTraffic:        model.LogTraffic.ValueString(),
-		SkipLocalDestined: model.SkipLocalDestined.ValueString(),
-		Schedule:          model.Schedule.ValueString(),
-	}
-
-	// Add all the extra fields
-	if !model.DSCPMarking.IsNull() {
-		rule.NetworkPolicy.DSCPMarking = model.DSCPMarking.ValueString()
-	}
-	
-	if !model.WebFilter.IsNull() {
-		rule.NetworkPolicy.WebFilter = model.WebFilter.ValueString()
-	}
-	
-	if !model.WebCategoryBaseQoSPolicy.IsNull() {
-		rule.NetworkPolicy.WebCategoryBaseQoSPolicy = model.WebCategoryBaseQoSPolicy.ValueString()
+	if model.NetworkPolicy != nil {
+		rule.NetworkPolicy = modelToAPINetworkPolicy(*model.NetworkPolicy)
 	}
-	
-	if !model.BlockQuickQuic.IsNull() {
-		rule.NetworkPolicy.BlockQuickQuic = model.BlockQuickQuic.ValueString()
+	if model.UserPolicy != nil {
+		rule.UserPolicy = modelToAPIUserPolicy(*model.UserPolicy)
 	}
-	
-	if !model.ScanVirus.IsNull() {
-		rule.NetworkPolicy.ScanVirus = model.ScanVirus.ValueString()
-	}
-	
-	if !model.ZeroDayProtection.IsNull() {
-		rule.NetworkPolicy.ZeroDayProtection = model.ZeroDayProtection.ValueString()
-	}
-	
-	if !model.ProxyMode.IsNull() {
-		rule.NetworkPolicy.ProxyMode = model.ProxyMode.ValueString()
-	}
-	
-	if !model.DecryptHTTPS.IsNull() {
-		rule.NetworkPolicy.DecryptHTTPS = model.DecryptHTTPS.ValueString()
-	}
-	
-	if !model.ApplicationControl.IsNull() {
-		rule.NetworkPolicy.ApplicationControl = model.ApplicationControl.ValueString()
-	}
-	
-	if !model.ApplicationBaseQoSPolicy.IsNull() {
-		rule.NetworkPolicy.ApplicationBaseQoSPolicy = model.ApplicationBaseQoSPolicy.ValueString()
-	}
-	
-	if !model.IntrusionPrevention.IsNull() {
-		rule.NetworkPolicy.IntrusionPrevention = model.IntrusionPrevention.ValueString()
+
+	return rule
+}
+
+// modelToAPINetworkPolicy converts the network_policy block. Unset
+// attributes are left empty so that the appliance keeps its defaults.
+func modelToAPINetworkPolicy(model networkPolicyModel) *firewallrule.NetworkPolicy {
+	policy := &firewallrule.NetworkPolicy{
+		Action:                        model.Action.ValueString(),
+		LogTraffic:                    boolEnable(model.LogTraffic),
+		SkipLocalDestined:             boolEnable(model.SkipLocalDestined),
+		Schedule:                      model.Schedule.ValueString(),
+		DSCPMarking:                   model.DSCPMarking.ValueString(),
+		WebFilter:                     model.WebFilter.ValueString(),
+		WebCategoryBaseQoSPolicy:      model.WebCategoryBaseQoSPolicy.ValueString(),
+		BlockQuickQuic:                boolEnable(model.BlockQuickQuic),
+		ScanVirus:                     boolEnable(model.ScanVirus),
+		ZeroDayProtection:             boolEnable(model.ZeroDayProtection),
+		ProxyMode:                     boolEnable(model.ProxyMode),
+		DecryptHTTPS:                  boolEnable(model.DecryptHTTPS),
+		ApplicationControl:            model.ApplicationControl.ValueString(),
+		ApplicationBaseQoSPolicy:      model.ApplicationBaseQoSPolicy.ValueString(),
+		IntrusionPrevention:           model.IntrusionPrevention.ValueString(),
+		TrafficShappingPolicy:         model.TrafficShapingPolicy.ValueString(),
+		ScanSMTP:                      boolEnable(model.ScanSMTP),
+		ScanSMTPS:                     boolEnable(model.ScanSMTPS),
+		ScanIMAP:                      boolEnable(model.ScanIMAP),
+		ScanIMAPS:                     boolEnable(model.ScanIMAPS),
+		ScanPOP3:                      boolEnable(model.ScanPOP3),
+		ScanPOP3S:                     boolEnable(model.ScanPOP3S),
+		ScanFTP:                       boolEnable(model.ScanFTP),
+		SourceSecurityHeartbeat:       boolEnable(model.SourceSecurityHeartbeat),
+		MinimumSourceHBPermitted:      model.MinimumSourceHBPermitted.ValueString(),
+		DestSecurityHeartbeat:         boolEnable(model.DestSecurityHeartbeat),
+		MinimumDestinationHBPermitted: model.MinimumDestinationHBPermitted.ValueString(),
 	}
-	
-	if !model.TrafficShappingPolicy.IsNull() {
-		rule.NetworkPolicy.TrafficShappingPolicy = model.TrafficShappingPolicy.ValueString()
+
+	if len(model.SourceZones) > 0 {
+		policy.SourceZones = &firewallrule.ZoneList{Zones: setStrings(model.SourceZones)}
 	}
-	
-	if !model.ScanSMTP.IsNull() {
-		rule.NetworkPolicy.ScanSMTP = model.ScanSMTP.ValueString()
+	if len(model.DestinationZones) > 0 {
+		policy.DestinationZones = &firewallrule.ZoneList{Zones: setStrings(model.DestinationZones)}
 	}
-	
-	if !model.ScanSMTPS.IsNull() {
-		rule.NetworkPolicy.ScanSMTPS = model.ScanSMTPS.ValueString()
+	if len(model.SourceNetworks) > 0 {
+		policy.SourceNetworks = &firewallrule.NetworkList{Networks: setStrings(model.SourceNetworks)}
 	}
-	
-	if !model.ScanIMAP.IsNull() {
-		rule.NetworkPolicy.ScanIMAP = model.ScanIMAP.ValueString()
+	if len(model.DestinationNetworks) > 0 {
+		policy.DestinationNetworks = &firewallrule.NetworkList{Networks: setStrings(model.DestinationNetworks)}
 	}
-	
-	if !model.ScanIMAPS.IsNull() {
-		rule.NetworkPolicy.ScanIMAPS = model.ScanIMAPS.ValueString()
+	if len(model.Services) > 0 {
+		policy.Services = &firewallrule.ServiceList{Services: setStrings(model.Services)}
 	}
-	
-	if !model.ScanPOP3.IsNull() {
-		rule.NetworkPolicy.ScanPOP3 = model.ScanPOP3.ValueString()
+
+	return policy
+}
+
+// modelToAPIUserPolicy converts the user_policy block
+func modelToAPIUserPolicy(model userPolicyModel) *firewallrule.UserPolicy {
+	policy := &firewallrule.UserPolicy{
+		NetworkPolicy:     *modelToAPINetworkPolicy(model.networkPolicyModel),
+		MatchIdentity:     boolEnable(model.MatchIdentity),
+		ShowCaptivePortal: boolEnable(model.ShowCaptivePortal),
+		DataAccounting:    boolEnable(model.DataAccounting),
 	}
-	
-	if !model.ScanPOP3S.IsNull() {
-		rule.NetworkPolicy.ScanPOP3S = model.ScanPOP3S.ValueString()
+	if len(model.Identity) > 0 {
+		policy.Identity = &firewallrule.IdentityList{Members: setStrings(model.Identity)}
 	}
-	
-	if !model.ScanFTP.IsNull() {
-		rule.NetworkPolicy.ScanFTP = model.ScanFTP.ValueString()
+	return policy
+}
+
+// Helper method to convert from API structure to Terraform model
+func apiToModelFirewallRule(rule firewallrule.FirewallRule) firewallRuleModel {
+	model := firewallRuleModel{
+		Name:        types.StringValue(rule.Name),
+		Description: types.StringValue(rule.Description),
+		IPFamily:    types.StringValue(rule.IPFamily),
+		Enabled:     enableBool(rule.Status),
+		Position:    types.StringValue(rule.Position),
+		PolicyType:  types.StringValue(rule.PolicyType),
+		AfterRule:   types.StringNull(),
+		BeforeRule:  types.StringNull(),
 	}
-	
-	if !model.SourceSecurityHeartbeat.IsNull() {
-		rule.NetworkPolicy.SourceSecurityHeartbeat = model.SourceSecurityHeartbeat.ValueString()
+
+	// Set position references
+	if rule.After != nil {
+		model.AfterRule = types.StringValue(rule.After.Name)
 	}
-	
-	if !model.MinimumSourceHBPermitted.IsNull() {
-		rule.NetworkPolicy.MinimumSourceHBPermitted = model.MinimumSourceHBPermitted.ValueString()
+	if rule.Before != nil {
+		model.BeforeRule = types.StringValue(rule.Before.Name)
 	}
-	
-	if !model.DestSecurityHeartbeat.IsNull() {
-		rule.NetworkPolicy.DestSecurityHeartbeat = model.DestSecurityHeartbeat.ValueString()
+
+	if rule.NetworkPolicy != nil {
+		model.NetworkPolicy = apiToModelNetworkPolicy(*rule.NetworkPolicy)
 	}
-	
-	if !model.MinimumDestinationHBPermitted.IsNull() {
-		rule.NetworkPolicy.MinimumDestinationHBPermitted = model.MinimumDestinationHBPermitted.ValueString()
+	if rule.UserPolicy != nil {
+		model.UserPolicy = apiToModelUserPolicy(*rule.UserPolicy)
 	}
 
-	// Source Zones
-	if len(model.SourceZones) > 0 {
-		rule.NetworkPolicy.SourceZones = &firewallrule.ZoneList{
-			Zones: make([]string, 0, len(model.SourceZones)),
-		}
-		for _, zone := range model.SourceZones {
-			rule.NetworkPolicy.SourceZones.Zones = append(rule.NetworkPolicy.SourceZones.Zones, zone.ValueString())
-		}
-	}
+	return model
+}
 
-	// Destination Zones
-	if len(model.DestinationZones) > 0 {
-		rule.NetworkPolicy.DestinationZones = &firewallrule.ZoneList{
-			Zones: make([]string, 0, len(model.DestinationZones)),
-		}
-		for _, zone := range model.DestinationZones {
-			rule.NetworkPolicy.DestinationZones.Zones = append(rule.NetworkPolicy.DestinationZones.Zones, zone.ValueString())
-		}
-	}
+// apiToModelNetworkPolicy converts the network policy of a rule
+func apiToModelNetworkPolicy(policy firewallrule.NetworkPolicy) *networkPolicyModel {
+	model := &networkPolicyModel{
+		Action:                        types.StringValue(policy.Action),
+		LogTraffic:                    enableBool(policy.LogTraffic),
+		SkipLocalDestined:             enableBool(policy.SkipLocalDestined),
+		Schedule:                      types.StringValue(policy.Schedule),
+		DSCPMarking:                   types.StringValue(policy.DSCPMarking),
+		WebFilter:                     types.StringValue(policy.WebFilter),
+		WebCategoryBaseQoSPolicy:      types.StringValue(policy.WebCategoryBaseQoSPolicy),
+		BlockQuickQuic:                enableBool(policy.BlockQuickQuic),
+		ScanVirus:                     enableBool(policy.ScanVirus),
+		ZeroDayProtection:             enableBool(policy.ZeroDayProtection),
+		ProxyMode:                     enableBool(policy.ProxyMode),
+		DecryptHTTPS:                  enableBool(policy.DecryptHTTPS),
+		ApplicationControl:            types.StringValue(policy.ApplicationControl),
+		ApplicationBaseQoSPolicy:      types.StringValue(policy.ApplicationBaseQoSPolicy),
+		IntrusionPrevention:           types.StringValue(policy.IntrusionPrevention),
+		TrafficShapingPolicy:          types.StringValue(policy.TrafficShappingPolicy),
+		ScanSMTP:                      enableBool(policy.ScanSMTP),
+		ScanSMTPS:                     enableBool(policy.ScanSMTPS),
+		ScanIMAP:                      enableBool(policy.ScanIMAP),
+		ScanIMAPS:                     enableBool(policy.ScanIMAPS),
+		ScanPOP3:                      enableBool(policy.ScanPOP3),
+		ScanPOP3S:                     enableBool(policy.ScanPOP3S),
+		ScanFTP:                       enableBool(policy.ScanFTP),
+		SourceSecurityHeartbeat:       enableBool(policy.SourceSecurityHeartbeat),
+		MinimumSourceHBPermitted:      types.StringValue(policy.MinimumSourceHBPermitted),
+		DestSecurityHeartbeat:         enableBool(policy.DestSecurityHeartbeat),
+		MinimumDestinationHBPermitted: types.StringValue(policy.MinimumDestinationHBPermitted),
+	}
+
+	if policy.SourceZones != nil {
+		model.SourceZones = stringSet(policy.SourceZones.Zones)
+	}
+	if policy.DestinationZones != nil {
+		model.DestinationZones = stringSet(policy.DestinationZones.Zones)
+	}
+	if policy.SourceNetworks != nil {
+		model.SourceNetworks = stringSet(policy.SourceNetworks.Networks)
+	}
+	if policy.DestinationNetworks != nil {
+		model.DestinationNetworks = stringSet(policy.DestinationNetworks.Networks)
+	}
+	if policy.Services != nil {
+		model.Services = stringSet(policy.Services.Services)
+	}
+
+	return model
+}
 
-	// Source Networks
-	if len(model.SourceNetworks) > 0 {
-		rule.NetworkPolicy.SourceNetworks = &firewallrule.NetworkList{
-			Networks: make([]string, 0, len(model.SourceNetworks)),
-		}
-		for _, network := range model.SourceNetworks {
-			rule.NetworkPolicy.SourceNetworks.Networks = append(rule.NetworkPolicy.SourceNetworks.Networks, network.ValueString())
-		}
+// apiToModelUserPolicy converts the user policy of an identity-based rule
+func apiToModelUserPolicy(policy firewallrule.UserPolicy) *userPolicyModel {
+	model := &userPolicyModel{
+		networkPolicyModel: *apiToModelNetworkPolicy(policy.NetworkPolicy),
+		MatchIdentity:      enableBool(policy.MatchIdentity),
+		ShowCaptivePortal:  enableBool(policy.ShowCaptivePortal),
+		DataAccounting:     enableBool(policy.DataAccounting),
 	}
-
-	// Destination Networks
-	if len(model.DestinationNetworks) > 0 {
-		rule.NetworkPolicy.DestinationNetworks = &firewallrule.NetworkList{
-			Networks: make([]string, 0, len(model.DestinationNetworks)),
-		}
-		for _, network := range model.DestinationNetworks {
-			rule.NetworkPolicy.DestinationNetworks.Networks = append(rule.NetworkPolicy.DestinationNetworks.Networks, network.ValueString())
-		}
+	if policy.Identity != nil {
+		model.Identity = stringSet(policy.Identity.Members)
 	}
+	return model
+}
 
-	return rule
+// enableBool converts an Enable/Disable value of the XML API. Any other
+// value, including an absent one, is null.
+func enableBool(value string) types.Bool {
+	switch value {
+	case "Enable":
+		return types.BoolValue(true)
+	case "Disable":
+		return types.BoolValue(false)
+	}
+	return types.BoolNull()
 }
 
-// Helper method to convert from API structure to Terraform model
-func (r *firewallRuleResource) apiToModelFirewallRule(rule firewallrule.FirewallRule) firewallRuleResourceModel {
-    model := firewallRuleResourceModel{
-        Name:        types.StringValue(rule.Name),
-        Description: types.StringValue(rule.Description),
-        IPFamily:    types.StringValue(rule.IPFamily),
-        Status:      types.StringValue(rule.Status),
-        Position:    types.StringValue(rule.Position),
-        PolicyType:  types.StringValue(rule.PolicyType),
-    }
-
-    // Set position references
-    if rule.After != nil {
-        model.AfterRule = types.StringValue(rule.After.Name)
-    } else {
-        model.AfterRule = types.StringNull()
-    }
-
-    if rule.Before != nil {
-        model.BeforeRule = types.StringValue(rule.Before.Name)
-    } else {
-        model.BeforeRule = types.StringNull()
-    }
-
-    // Set network policy attributes
-    if rule.NetworkPolicy != nil {
-        model.Action = types.StringValue(rule.NetworkPolicy.Action)
-        model.LogTraffic = types.StringValue(rule.NetworkPolicy.LogTraffic)
-        model.SkipLocalDestined = types.StringValue(rule.NetworkPolicy.SkipLocalDestined)
-        model.Schedule = types.StringValue(rule.NetworkPolicy.Schedule)
-
-        // Map all additional fields from NetworkPolicy
-        model.DSCPMarking = types.StringValue(rule.NetworkPolicy.DSCPMarking)
-        model.WebFilter = types.StringValue(rule.NetworkPolicy.WebFilter)
-        model.WebCategoryBaseQoSPolicy = types.StringValue(rule.NetworkPolicy.WebCategoryBaseQoSPolicy)
-        model.BlockQuickQuic = types.StringValue(rule.NetworkPolicy.BlockQuickQuic)
-        model.ScanVirus = types.StringValue(rule.NetworkPolicy.ScanVirus)
-        model.ZeroDayProtection = types.StringValue(rule.NetworkPolicy.ZeroDayProtection)
-        model.ProxyMode = types.StringValue(rule.NetworkPolicy.ProxyMode)
-        model.DecryptHTTPS = types.StringValue(rule.NetworkPolicy.DecryptHTTPS)
-        model.ApplicationControl = types.StringValue(rule.NetworkPolicy.ApplicationControl)
-        model.ApplicationBaseQoSPolicy = types.StringValue(rule.NetworkPolicy.ApplicationBaseQoSPolicy)
-        model.IntrusionPrevention = types.StringValue(rule.NetworkPolicy.IntrusionPrevention)
-        model.TrafficShappingPolicy = types.StringValue(rule.NetworkPolicy.TrafficShappingPolicy) // Corrected typo: TrafficShapingPolicy
-        model.ScanSMTP = types.StringValue(rule.NetworkPolicy.ScanSMTP)
-        model.ScanSMTPS = types.StringValue(rule.NetworkPolicy.ScanSMTPS)
-        model.ScanIMAP = types.StringValue(rule.NetworkPolicy.ScanIMAP)
-        model.ScanIMAPS = types.StringValue(rule.NetworkPolicy.ScanIMAPS)
-        model.ScanPOP3 = types.StringValue(rule.NetworkPolicy.ScanPOP3)
-        model.ScanPOP3S = types.StringValue(rule.NetworkPolicy.ScanPOP3S)
-        model.ScanFTP = types.StringValue(rule.NetworkPolicy.ScanFTP)
-        model.SourceSecurityHeartbeat = types.StringValue(rule.NetworkPolicy.SourceSecurityHeartbeat)
-        model.MinimumSourceHBPermitted = types.StringValue(rule.NetworkPolicy.MinimumSourceHBPermitted)
-        model.DestSecurityHeartbeat = types.StringValue(rule.NetworkPolicy.DestSecurityHeartbeat)
-        model.MinimumDestinationHBPermitted = types.StringValue(rule.NetworkPolicy.MinimumDestinationHBPermitted)
-
-        // Source Zones
-        if rule.NetworkPolicy.SourceZones != nil {
-            model.SourceZones = make([]types.String, 0, len(rule.NetworkPolicy.SourceZones.Zones))
-            for _, zone := range rule.NetworkPolicy.SourceZones.Zones {
-                model.SourceZones = append(model.SourceZones, types.StringValue(zone))
-            }
-        } else {
-            model.SourceZones = nil // Or []types.String{} depending on desired null/empty representation
-        }
-
-        // Destination Zones
-        if rule.NetworkPolicy.DestinationZones != nil {
-            model.DestinationZones = make([]types.String, 0, len(rule.NetworkPolicy.DestinationZones.Zones))
-            for _, zone := range rule.NetworkPolicy.DestinationZones.Zones {
-                model.DestinationZones = append(model.DestinationZones, types.StringValue(zone))
-            }
-        } else {
-            model.DestinationZones = nil
-        }
-
-        // Source Networks
-        if rule.NetworkPolicy.SourceNetworks != nil {
-            model.SourceNetworks = make([]types.String, 0, len(rule.NetworkPolicy.SourceNetworks.Networks))
-            for _, network := range rule.NetworkPolicy.SourceNetworks.Networks {
-                model.SourceNetworks = append(model.SourceNetworks, types.StringValue(network))
-            }
-        } else {
-            model.SourceNetworks = nil
-        }
-
-        // Destination Networks
-        if rule.NetworkPolicy.DestinationNetworks != nil {
-            model.DestinationNetworks = make([]types.String, 0, len(rule.NetworkPolicy.DestinationNetworks.Networks))
-            for _, network := range rule.NetworkPolicy.DestinationNetworks.Networks {
-                model.DestinationNetworks = append(model.DestinationNetworks, types.StringValue(network))
-            }
-        } else {
-            model.DestinationNetworks = nil
-        }
-
-    } else {
-        // Handle case where NetworkPolicy is nil (e.g., set defaults or nulls)
-        model.Action = types.StringNull()
-        model.LogTraffic = types.StringNull() // Or default value like types.StringValue("Disable")
-        model.SkipLocalDestined = types.StringNull() // Or default
-        model.Schedule = types.StringNull() // Or default like types.StringValue("AllTheTime")
-        model.DSCPMarking = types.StringNull()
-        model.WebFilter = types.StringNull() // Or default like types.StringValue("None")
-        model.WebCategoryBaseQoSPolicy = types.StringNull() // Or default
-        model.BlockQuickQuic = types.StringNull() // Or default
-        model.ScanVirus = types.StringNull() // Or default
-        model.ZeroDayProtection = types.StringNull() // Or default
-        model.ProxyMode = types.StringNull() // Or default
-        model.DecryptHTTPS = types.StringNull() // Or default
-        model.ApplicationControl = types.StringNull() // Or default
-        model.ApplicationBaseQoSPolicy = types.StringNull() // Or default
-        model.IntrusionPrevention = types.StringNull() // Or default
-        model.TrafficShappingPolicy = types.StringNull() // Or default
-        model.ScanSMTP = types.StringNull() // Or default
-        model.ScanSMTPS = types.StringNull() // Or default
-        model.ScanIMAP = types.StringNull() // Or default
-        model.ScanIMAPS = types.StringNull() // Or default
-        model.ScanPOP3 = types.StringNull() // Or default
-        model.ScanPOP3S = types.StringNull() // Or default
-        model.ScanFTP = types.StringNull() // Or default
-        model.SourceSecurityHeartbeat = types.StringNull() // Or default
-        model.MinimumSourceHBPermitted = types.StringNull() // Or default
-        model.DestSecurityHeartbeat = types.StringNull() // Or default
-        model.MinimumDestinationHBPermitted = types.StringNull() // Or default
-        model.SourceZones = nil
-        model.DestinationZones = nil
-        model.SourceNetworks = nil
-        model.DestinationNetworks = nil
-    }
-
-    return model
-}
\ No newline at end of file
+// boolEnable converts a boolean to the Enable/Disable value of the XML API.
+// A null or unknown value is left empty.
+func boolEnable(value types.Bool) string {
+	if value.IsNull() || value.IsUnknown() {
+		return ""
+	}
+	if value.ValueBool() {
+		return "Enable"
+	}
+	return "Disable"
+}
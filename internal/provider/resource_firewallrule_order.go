@@ -0,0 +1,337 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/firewallrule"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var _ resource.Resource = &firewallRuleOrderResource{}
+var _ resource.ResourceWithImportState = &firewallRuleOrderResource{}
+var _ resource.ResourceWithValidateConfig = &firewallRuleOrderResource{}
+
+// firewallRuleOrderResource is the resource implementation
+type firewallRuleOrderResource struct {
+	client *firewallrule.Client
+}
+
+// firewallRuleOrderResourceModel maps the resource schema data
+type firewallRuleOrderResourceModel struct {
+	Rules    []types.String `tfsdk:"rules"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// NewFirewallRuleOrderResource creates a new resource
+func NewFirewallRuleOrderResource() resource.Resource {
+	return &firewallRuleOrderResource{}
+}
+
+// Metadata returns the resource type name
+func (r *firewallRuleOrderResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_firewall_rule_order"
+}
+
+// Schema defines the schema for the resource
+func (r *firewallRuleOrderResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the relative order of Sophos Firewall rules",
+		Attributes: map[string]schema.Attribute{
+			"rules": schema.ListAttribute{
+				Description: "Names of the rules in the order the firewall should evaluate them. Rules not listed keep their position.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *firewallRuleOrderResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*SophosClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *SophosClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = firewallrule.NewClient(client.BaseClient)
+}
+
+// ValidateConfig rejects rules listed more than once
+func (r *firewallRuleOrderResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config firewallRuleOrderResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seen := make(map[string]bool)
+	for i, rule := range config.Rules {
+		if rule.IsNull() || rule.IsUnknown() {
+			continue
+		}
+		if seen[rule.ValueString()] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rules").AtListIndex(i),
+				"Duplicate Firewall Rule",
+				fmt.Sprintf("Firewall rule %s is listed more than once.", rule.ValueString()),
+			)
+		}
+		seen[rule.ValueString()] = true
+	}
+}
+
+// Create moves the rules into the configured order
+func (r *firewallRuleOrderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan firewallRuleOrderResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	r.applyOrder(ctx, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the actual order of the rules
+func (r *firewallRuleOrderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state firewallRuleOrderResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	rules, err := r.client.ListFirewallRules(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error reading firewall rule order", err)
+		return
+	}
+
+	// Keep the managed rules in the order of the firewall. Deleted rules
+	// drop out, so the plan shows them as missing.
+	managed := make(map[string]bool, len(state.Rules))
+	for _, rule := range state.Rules {
+		managed[rule.ValueString()] = true
+	}
+	state.Rules = []types.String{}
+	for _, rule := range rules {
+		if managed[rule.Name] {
+			state.Rules = append(state.Rules, types.StringValue(rule.Name))
+		}
+	}
+
+	tflog.Debug(ctx, "Retrieved firewall rule order", map[string]interface{}{"rules": state.Rules})
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update moves the rules into the configured order
+func (r *firewallRuleOrderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan firewallRuleOrderResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	r.applyOrder(ctx, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the resource from the Terraform state. The rules keep
+// their position on the firewall.
+func (r *firewallRuleOrderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// ImportState imports the order of a comma-separated list of rule names
+func (r *firewallRuleOrderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var rules []types.String
+	for _, name := range strings.Split(req.ID, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			rules = append(rules, types.StringValue(name))
+		}
+	}
+	if len(rules) == 0 {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected a comma-separated list of firewall rule names, got: %q", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("rules"), rules)...)
+}
+
+// applyOrder reads the actual rule order and applies the moves that bring
+// the planned rules into the planned order
+func (r *firewallRuleOrderResource) applyOrder(ctx context.Context, plan firewallRuleOrderResourceModel, diags *diag.Diagnostics) {
+	rules, err := r.client.ListFirewallRules(ctx)
+	if err != nil {
+		addClientError(diags, "Error reading firewall rule order", err)
+		return
+	}
+
+	byName := make(map[string]firewallrule.FirewallRule, len(rules))
+	var current []string
+	for _, rule := range rules {
+		byName[rule.Name] = rule
+		current = append(current, rule.Name)
+	}
+
+	desired := make([]string, 0, len(plan.Rules))
+	for _, rule := range plan.Rules {
+		if _, ok := byName[rule.ValueString()]; !ok {
+			diags.AddError(
+				"Firewall Rule Not Found",
+				fmt.Sprintf("Firewall rule %s does not exist, so it cannot be ordered.", rule.ValueString()),
+			)
+			continue
+		}
+		desired = append(desired, rule.ValueString())
+	}
+	if diags.HasError() {
+		return
+	}
+
+	moves := planRuleMoves(current, desired)
+	tflog.Debug(ctx, "Moving firewall rules", map[string]interface{}{"moves": fmt.Sprintf("%+v", moves)})
+	for _, move := range moves {
+		if err := r.client.MoveFirewallRule(ctx, byName[move.Rule], move.Position, move.Anchor); err != nil {
+			addClientError(diags, "Error moving firewall rule", err)
+			return
+		}
+	}
+}
+
+// ruleMove moves a rule directly after or before the anchor rule
+type ruleMove struct {
+	Rule     string
+	Position string
+	Anchor   string
+}
+
+// planRuleMoves returns the fewest moves that bring the desired rules into
+// the desired order. current is the order of all rules on the firewall.
+// The longest run of desired rules already in order stays in place, every
+// other desired rule is moved after its predecessor, or before its
+// successor when it comes first. Rules not desired are not moved.
+func planRuleMoves(current, desired []string) []ruleMove {
+	index := make(map[string]int, len(current))
+	for i, name := range current {
+		index[name] = i
+	}
+	positions := make([]int, len(desired))
+	for i, name := range desired {
+		positions[i] = index[name]
+	}
+	stable := longestIncreasing(positions)
+
+	var moves []ruleMove
+	for i, name := range desired {
+		if stable[i] {
+			continue
+		}
+		if i > 0 {
+			moves = append(moves, ruleMove{Rule: name, Position: firewallrule.PositionAfter, Anchor: desired[i-1]})
+			continue
+		}
+		// The first rule goes before the first rule that stays in place.
+		// The rules moved after it end up between the two.
+		first := slices.Index(stable, true)
+		moves = append(moves, ruleMove{Rule: name, Position: firewallrule.PositionBefore, Anchor: desired[first]})
+	}
+	return moves
+}
+
+// longestIncreasing marks a longest strictly increasing subsequence of the
+// values
+func longestIncreasing(values []int) []bool {
+	// tails[k] is the index of the smallest value ending an increasing
+	// subsequence of length k+1, prev links each index to its predecessor
+	var tails []int
+	prev := make([]int, len(values))
+	for i, value := range values {
+		k, _ := slices.BinarySearchFunc(tails, value, func(tail, value int) int {
+			return values[tail] - value
+		})
+		prev[i] = -1
+		if k > 0 {
+			prev[i] = tails[k-1]
+		}
+		if k == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[k] = i
+		}
+	}
+
+	marked := make([]bool, len(values))
+	if len(tails) > 0 {
+		for i := tails[len(tails)-1]; i >= 0; i = prev[i] {
+			marked[i] = true
+		}
+	}
+	return marked
+}
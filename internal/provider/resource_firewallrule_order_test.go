@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/firewallrule"
+)
+
+// applyRuleMoves returns the rule order after the moves
+func applyRuleMoves(t *testing.T, order []string, moves []ruleMove) []string {
+	t.Helper()
+	order = slices.Clone(order)
+	for _, move := range moves {
+		order = slices.DeleteFunc(order, func(name string) bool { return name == move.Rule })
+		anchor := slices.Index(order, move.Anchor)
+		if anchor < 0 {
+			t.Fatalf("move %+v refers to a missing rule", move)
+		}
+		if move.Position == firewallrule.PositionAfter {
+			anchor++
+		}
+		order = slices.Insert(order, anchor, move.Rule)
+	}
+	return order
+}
+
+// relativeOrder returns the names in order that are in names
+func relativeOrder(order, names []string) []string {
+	var relative []string
+	for _, name := range order {
+		if slices.Contains(names, name) {
+			relative = append(relative, name)
+		}
+	}
+	return relative
+}
+
+func TestPlanRuleMoves(t *testing.T) {
+	for _, tc := range []struct {
+		current, desired []string
+		moves            int
+	}{
+		{[]string{"a", "b", "c"}, []string{"a", "b", "c"}, 0},
+		{[]string{"a", "b", "c"}, []string{"c", "a", "b"}, 1},
+		{[]string{"a", "b", "c"}, []string{"b", "c", "a"}, 1},
+		{[]string{"a", "b", "c", "d"}, []string{"d", "c", "b", "a"}, 3},
+		{[]string{"x", "a", "y", "b", "z"}, []string{"b", "a"}, 1},
+		{[]string{"a", "b"}, []string{"b"}, 0},
+	} {
+		moves := planRuleMoves(tc.current, tc.desired)
+		if len(moves) != tc.moves {
+			t.Errorf("planRuleMoves(%v, %v) = %+v, want %d moves", tc.current, tc.desired, moves, tc.moves)
+		}
+		if got := relativeOrder(applyRuleMoves(t, tc.current, moves), tc.desired); !slices.Equal(got, tc.desired) {
+			t.Errorf("planRuleMoves(%v, %v) results in %v", tc.current, tc.desired, got)
+		}
+	}
+}
+
+func TestPlanRuleMovesRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < roundTripRuns; i++ {
+		var current []string
+		for j := 0; j < r.Intn(12); j++ {
+			current = append(current, fmt.Sprintf("rule%d", j))
+		}
+		desired := slices.Clone(current)
+		r.Shuffle(len(desired), func(i, j int) { desired[i], desired[j] = desired[j], desired[i] })
+		desired = desired[:r.Intn(len(desired)+1)]
+
+		moves := planRuleMoves(current, desired)
+		after := applyRuleMoves(t, current, moves)
+		if got := relativeOrder(after, desired); !slices.Equal(got, desired) {
+			t.Fatalf("planRuleMoves(%v, %v) results in %v", current, desired, got)
+		}
+		// Rules that are not ordered keep their relative order
+		var unmanaged []string
+		for _, name := range current {
+			if !slices.Contains(desired, name) {
+				unmanaged = append(unmanaged, name)
+			}
+		}
+		if got := relativeOrder(after, unmanaged); !slices.Equal(got, unmanaged) {
+			t.Fatalf("planRuleMoves(%v, %v) moves other rules: %v", current, desired, after)
+		}
+		if again := planRuleMoves(after, desired); len(again) != 0 {
+			t.Fatalf("planRuleMoves(%v, %v) is not idempotent: %+v", after, desired, again)
+		}
+	}
+}
+
+func TestAccFirewallRuleOrderResource(t *testing.T) {
+	server, providerConfig := testAccServer(t)
+	for _, name := range []string{"a", "b", "c", "default"} {
+		if err := server.Put(fmt.Sprintf(`<FirewallRule><Name>%s</Name><Position>Bottom</Position><PolicyType>Network</PolicyType>`+
+			`<NetworkPolicy><Action>Accept</Action></NetworkPolicy></FirewallRule>`, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "sophosfirewall_firewall_rule_order" "test" {
+  rules = ["c", "a", "b"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckRuleOrder(server, "c", "a", "b", "default"),
+					resource.TestCheckResourceAttr("sophosfirewall_firewall_rule_order.test", "rules.0", "c"),
+				),
+			},
+			// Order drift is corrected
+			{
+				PreConfig: func() {
+					if err := server.Put(`<FirewallRule><Name>c</Name><Position>Bottom</Position><PolicyType>Network</PolicyType>` +
+						`<NetworkPolicy><Action>Accept</Action></NetworkPolicy></FirewallRule>`); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: providerConfig + `
+resource "sophosfirewall_firewall_rule_order" "test" {
+  rules = ["c", "a", "b"]
+}
+`,
+				Check: testAccCheckRuleOrder(server, "c", "a", "b", "default"),
+			},
+			{
+				Config: providerConfig + `
+resource "sophosfirewall_firewall_rule_order" "test" {
+  rules = ["b", "a"]
+}
+`,
+				Check: testAccCheckRuleOrder(server, "c", "b", "a", "default"),
+			},
+			{
+				ResourceName:                         "sophosfirewall_firewall_rule_order.test",
+				ImportState:                          true,
+				ImportStateId:                        "b,a",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "rules.0",
+				ImportStateVerifyIgnore:              []string{"timeouts"},
+			},
+			{
+				Config: providerConfig + `
+resource "sophosfirewall_firewall_rule_order" "test" {
+  rules = ["b", "missing"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Firewall rule missing does not exist`),
+			},
+			{
+				Config: providerConfig + `
+resource "sophosfirewall_firewall_rule_order" "test" {
+  rules = ["a", "b", "a"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Duplicate Firewall Rule`),
+			},
+		},
+	})
+}
+
+// testAccFirewallRuleOrderWithRuleConfig returns a rule placed at the top
+// and an order resource that moves it after another rule
+func testAccFirewallRuleOrderWithRuleConfig(description string) string {
+	return fmt.Sprintf(`
+resource "sophosfirewall_firewallrule" "b" {
+  name        = "b"
+  description = %q
+  position    = "Top"
+  policy_type = "Network"
+
+  network_policy {
+    action            = "Accept"
+    source_zones      = ["LAN"]
+    destination_zones = ["WAN"]
+  }
+}
+
+resource "sophosfirewall_firewall_rule_order" "test" {
+  rules = ["a", sophosfirewall_firewallrule.b.name]
+}
+`, description)
+}
+
+// The rule resource does not move a rule back that the order resource moved
+func TestAccFirewallRuleOrderResourceWithRule(t *testing.T) {
+	server, providerConfig := testAccServer(t)
+	for _, name := range []string{"a", "default"} {
+		if err := server.Put(fmt.Sprintf(`<FirewallRule><Name>%s</Name><Position>Bottom</Position><PolicyType>Network</PolicyType>`+
+			`<NetworkPolicy><Action>Accept</Action></NetworkPolicy></FirewallRule>`, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccFirewallRuleOrderWithRuleConfig("Allow"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckRuleOrder(server, "a", "b", "default"),
+					resource.TestCheckResourceAttr("sophosfirewall_firewallrule.b", "position", "Top"),
+				),
+			},
+			{
+				Config: providerConfig + testAccFirewallRuleOrderWithRuleConfig("Allow all"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckRuleOrder(server, "a", "b", "default"),
+					resource.TestCheckResourceAttr("sophosfirewall_firewallrule.b", "description", "Allow all"),
+					resource.TestCheckResourceAttr("sophosfirewall_firewallrule.b", "position", "Top"),
+				),
+			},
+		},
+	})
+}
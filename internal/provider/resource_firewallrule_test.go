@@ -0,0 +1,299 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/testserver"
+)
+
+func testAccFirewallRuleConfig(action string) string {
+	return fmt.Sprintf(`
+resource "sophosfirewall_iphost" "web" {
+  name        = "web"
+  ip_family   = "IPv4"
+  host_type   = "IP"
+  ip_address  = "10.0.0.1"
+  host_groups = []
+}
+
+resource "sophosfirewall_firewallrule" "test" {
+  name                 = "allow-web"
+  description          = "Allow web traffic"
+  enabled              = true
+  position             = "Top"
+  policy_type          = "Network"
+
+  network_policy {
+    action               = %q
+    log_traffic          = false
+    schedule             = "All The Time"
+    source_zones         = ["LAN", "WAN"]
+    destination_zones    = ["DMZ"]
+    destination_networks = [sophosfirewall_iphost.web.name]
+  }
+}
+`, action)
+}
+
+// testAccCheckRuleOrder verifies the rule order on the server
+func testAccCheckRuleOrder(server *testserver.Server, want ...string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		if got := server.Names("FirewallRule"); !slices.Equal(got, want) {
+			return fmt.Errorf("rule order = %v, want %v", got, want)
+		}
+		return nil
+	}
+}
+
+func TestAccFirewallRuleResource(t *testing.T) {
+	server, providerConfig := testAccServer(t)
+	if err := server.Put(`<FirewallRule><Name>default</Name><Position>Bottom</Position></FirewallRule>`); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			testAccCheckEntityDestroyed(server, "FirewallRule", "allow-web"),
+			testAccCheckEntityDestroyed(server, "IPHost", "web"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccFirewallRuleConfig("Accept"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckRuleOrder(server, "allow-web", "default"),
+					resource.TestCheckResourceAttr("sophosfirewall_firewallrule.test", "network_policy.action", "Accept"),
+					resource.TestCheckResourceAttr("sophosfirewall_firewallrule.test", "network_policy.log_traffic", "false"),
+					resource.TestCheckTypeSetElemAttr("sophosfirewall_firewallrule.test", "network_policy.source_zones.*", "WAN"),
+					resource.TestCheckTypeSetElemAttr("sophosfirewall_firewallrule.test", "network_policy.destination_networks.*", "web"),
+				),
+			},
+			{
+				Config: providerConfig + testAccFirewallRuleConfig("Drop"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckRuleOrder(server, "allow-web", "default"),
+					resource.TestCheckResourceAttr("sophosfirewall_firewallrule.test", "network_policy.action", "Drop"),
+				),
+			},
+			{
+				ResourceName:                         "sophosfirewall_firewallrule.test",
+				ImportState:                          true,
+				ImportStateId:                        "allow-web",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+			},
+		},
+	})
+}
+
+func TestAccFirewallRuleResourceUserPolicy(t *testing.T) {
+	server, providerConfig := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckEntityDestroyed(server, "FirewallRule", "allow-staff"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "sophosfirewall_firewallrule" "test" {
+  name        = "allow-staff"
+  policy_type = "User"
+
+  user_policy {
+    action              = "Accept"
+    source_zones        = ["LAN"]
+    destination_zones   = ["WAN"]
+    identity            = ["Open Group", "staff"]
+    match_identity      = true
+    show_captive_portal = false
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sophosfirewall_firewallrule.test", "user_policy.action", "Accept"),
+					resource.TestCheckTypeSetElemAttr("sophosfirewall_firewallrule.test", "user_policy.identity.*", "staff"),
+					resource.TestCheckResourceAttr("sophosfirewall_firewallrule.test", "user_policy.match_identity", "true"),
+					resource.TestCheckNoResourceAttr("sophosfirewall_firewallrule.test", "network_policy.action"),
+					func(*terraform.State) error {
+						rule, _ := server.Entity("FirewallRule", "allow-staff")
+						if !strings.Contains(rule, "<UserPolicy>") || !strings.Contains(rule, "<MatchIdentity>Enable</MatchIdentity>") {
+							return fmt.Errorf("stored rule %s has no user policy", rule)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:                         "sophosfirewall_firewallrule.test",
+				ImportState:                          true,
+				ImportStateId:                        "allow-staff",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+			},
+			{
+				Config: providerConfig + `
+resource "sophosfirewall_firewallrule" "test" {
+  name        = "allow-staff"
+  policy_type = "User"
+
+  network_policy {
+    action            = "Accept"
+    source_zones      = ["LAN"]
+    destination_zones = ["WAN"]
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`requires a user_policy block`),
+			},
+			{
+				Config: providerConfig + `
+resource "sophosfirewall_firewallrule" "test" {
+  name        = "allow-staff"
+  policy_type = "User"
+
+  user_policy {
+    action            = "Accept"
+    source_zones      = ["LAN"]
+    destination_zones = ["WAN"]
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`requires the identity attribute`),
+			},
+		},
+	})
+}
+
+// randomFirewallRuleModel sets every attribute of the model to a random value,
+// so attributes added later are covered without changing the test
+func randomFirewallRuleModel(t *testing.T, r *rand.Rand) firewallRuleModel {
+	var model firewallRuleModel
+	randomFields(t, r, reflect.ValueOf(&model).Elem())
+	// A position reference is either unset or names a rule
+	model.AfterRule = randomOptional(r)
+	model.BeforeRule = randomOptional(r)
+	return model
+}
+
+// randomFields sets every field of a model struct, including nested
+// blocks, to a random value
+func randomFields(t *testing.T, r *rand.Rand, fields reflect.Value) {
+	t.Helper()
+	for i := 0; i < fields.NumField(); i++ {
+		// Embedded attributes belong to the same block
+		if fields.Type().Field(i).Anonymous {
+			randomFields(t, r, fields.Field(i))
+			continue
+		}
+		switch field := fields.Field(i).Addr().Interface().(type) {
+		case *types.String:
+			*field = types.StringValue(randomText(r))
+		case *types.Bool:
+			*field = types.BoolValue(r.Intn(2) == 0)
+		case *[]types.String:
+			// Sets hold no duplicates
+			if list := randomList(r); list != nil {
+				*field = stringSet(setStrings(list))
+			}
+		default:
+			// Nested blocks
+			if value := fields.Field(i); value.Kind() == reflect.Pointer && value.Type().Elem().Kind() == reflect.Struct {
+				value.Set(reflect.New(value.Type().Elem()))
+				randomFields(t, r, value.Elem())
+				continue
+			}
+			t.Fatalf("no random value for attribute %s", fields.Type().Field(i).Name)
+		}
+	}
+}
+
+func TestFirewallRuleModelRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < roundTripRuns; i++ {
+		model := randomFirewallRuleModel(t, r)
+		got := apiToModelFirewallRule(*modelToAPIFirewallRule(model))
+		if !reflect.DeepEqual(got, model) {
+			t.Fatalf("round trip of\n%+v\n= %+v", model, got)
+		}
+	}
+}
+
+func TestFirewallRuleStateUpgradeV0(t *testing.T) {
+	ctx := context.Background()
+	schemaV0 := firewallRuleSchemaV0(ctx)
+	var schemaResp fwresource.SchemaResponse
+	(&firewallRuleResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	timeoutsValue := timeouts.Value{Object: types.ObjectNull(map[string]attr.Type{
+		"create": types.StringType,
+		"read":   types.StringType,
+		"update": types.StringType,
+		"delete": types.StringType,
+	})}
+
+	prior := firewallRuleResourceModelV0{
+		Name:                  types.StringValue("allow-web"),
+		Description:           types.StringValue(""),
+		IPFamily:              types.StringValue("IPv4"),
+		Status:                types.StringValue("Enable"),
+		Position:              types.StringValue("Top"),
+		PolicyType:            types.StringValue("Network"),
+		AfterRule:             types.StringNull(),
+		BeforeRule:            types.StringNull(),
+		Action:                types.StringValue("Accept"),
+		LogTraffic:            types.StringValue("Disable"),
+		SkipLocalDestined:     types.StringValue(""),
+		Schedule:              types.StringValue("All The Time"),
+		SourceZones:           []types.String{types.StringValue("LAN"), types.StringValue("WAN"), types.StringValue("LAN")},
+		DestinationZones:      []types.String{types.StringValue("DMZ")},
+		TrafficShappingPolicy: types.StringValue("None"),
+		ScanVirus:             types.StringValue("Enable"),
+		Timeouts:              timeoutsValue,
+	}
+	req := fwresource.UpgradeStateRequest{State: &tfsdk.State{Schema: schemaV0}}
+	if diags := req.State.Set(ctx, prior); diags.HasError() {
+		t.Fatalf("set v0 state: %v", diags)
+	}
+	resp := fwresource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	upgradeFirewallRuleStateV0(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("upgrade: %v", resp.Diagnostics)
+	}
+
+	var got firewallRuleResourceModel
+	if diags := resp.State.Get(ctx, &got); diags.HasError() {
+		t.Fatalf("get v1 state: %v", diags)
+	}
+	if !got.Enabled.Equal(types.BoolValue(true)) || got.Name.ValueString() != "allow-web" {
+		t.Errorf("upgraded rule = %+v", got.firewallRuleModel)
+	}
+	policy := got.NetworkPolicy
+	if policy == nil {
+		t.Fatal("upgraded rule has no network policy")
+	}
+	if policy.Action.ValueString() != "Accept" || policy.TrafficShapingPolicy.ValueString() != "None" {
+		t.Errorf("upgraded network policy = %+v", policy)
+	}
+	if !policy.LogTraffic.Equal(types.BoolValue(false)) || !policy.ScanVirus.Equal(types.BoolValue(true)) || !policy.SkipLocalDestined.IsNull() {
+		t.Errorf("upgraded Enable/Disable values = %v, %v, %v", policy.LogTraffic, policy.ScanVirus, policy.SkipLocalDestined)
+	}
+	if want := []string{"LAN", "WAN"}; !slices.Equal(setStrings(policy.SourceZones), want) {
+		t.Errorf("upgraded source zones = %v, want %v", policy.SourceZones, want)
+	}
+	if policy.SourceNetworks != nil || policy.Services != nil {
+		t.Errorf("upgraded unset networks = %v, services = %v", policy.SourceNetworks, policy.Services)
+	}
+}
@@ -0,0 +1,342 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// firewallRuleResourceModelV0 maps the flat resource schema of version 0
+type firewallRuleResourceModelV0 struct {
+	Name                          types.String   `tfsdk:"name"`
+	Description                   types.String   `tfsdk:"description"`
+	IPFamily                      types.String   `tfsdk:"ip_family"`
+	Status                        types.String   `tfsdk:"status"`
+	Position                      types.String   `tfsdk:"position"`
+	PolicyType                    types.String   `tfsdk:"policy_type"`
+	AfterRule                     types.String   `tfsdk:"after_rule"`
+	BeforeRule                    types.String   `tfsdk:"before_rule"`
+	Action                        types.String   `tfsdk:"action"`
+	LogTraffic                    types.String   `tfsdk:"log_traffic"`
+	SkipLocalDestined             types.String   `tfsdk:"skip_local_destined"`
+	SourceZones                   []types.String `tfsdk:"source_zones"`
+	DestinationZones              []types.String `tfsdk:"destination_zones"`
+	Schedule                      types.String   `tfsdk:"schedule"`
+	SourceNetworks                []types.String `tfsdk:"source_networks"`
+	DestinationNetworks           []types.String `tfsdk:"destination_networks"`
+	DSCPMarking                   types.String   `tfsdk:"dscp_marking"`
+	WebFilter                     types.String   `tfsdk:"web_filter"`
+	WebCategoryBaseQoSPolicy      types.String   `tfsdk:"web_category_base_qos_policy"`
+	BlockQuickQuic                types.String   `tfsdk:"block_quick_quic"`
+	ScanVirus                     types.String   `tfsdk:"scan_virus"`
+	ZeroDayProtection             types.String   `tfsdk:"zero_day_protection"`
+	ProxyMode                     types.String   `tfsdk:"proxy_mode"`
+	DecryptHTTPS                  types.String   `tfsdk:"decrypt_https"`
+	ApplicationControl            types.String   `tfsdk:"application_control"`
+	ApplicationBaseQoSPolicy      types.String   `tfsdk:"application_base_qos_policy"`
+	IntrusionPrevention           types.String   `tfsdk:"intrusion_prevention"`
+	TrafficShappingPolicy         types.String   `tfsdk:"traffic_shapping_policy"`
+	ScanSMTP                      types.String   `tfsdk:"scan_smtp"`
+	ScanSMTPS                     types.String   `tfsdk:"scan_smtps"`
+	ScanIMAP                      types.String   `tfsdk:"scan_imap"`
+	ScanIMAPS                     types.String   `tfsdk:"scan_imaps"`
+	ScanPOP3                      types.String   `tfsdk:"scan_pop3"`
+	ScanPOP3S                     types.String   `tfsdk:"scan_pop3s"`
+	ScanFTP                       types.String   `tfsdk:"scan_ftp"`
+	SourceSecurityHeartbeat       types.String   `tfsdk:"source_security_heartbeat"`
+	MinimumSourceHBPermitted      types.String   `tfsdk:"minimum_source_hb_permitted"`
+	DestSecurityHeartbeat         types.String   `tfsdk:"dest_security_heartbeat"`
+	MinimumDestinationHBPermitted types.String   `tfsdk:"minimum_destination_hb_permitted"`
+	Timeouts                      timeouts.Value `tfsdk:"timeouts"`
+}
+
+// firewallRuleSchemaV0 returns the flat resource schema of version 0, in
+// which the network policy settings were top-level strings and the zones
+// and networks were lists
+func firewallRuleSchemaV0(ctx context.Context) schema.Schema {
+	return schema.Schema{
+		Description: "Manages a Sophos Firewall rule",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "Name of the firewall rule",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of the rule",
+				Optional:    true,
+			},
+			"ip_family": schema.StringAttribute{
+				Description: "IP Family (IPv4 or IPv6)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Status (Enable or Disable)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"position": schema.StringAttribute{
+				Description: "Position (Top, Bottom, After, Before)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"policy_type": schema.StringAttribute{
+				Description: "Policy Type (Network)",
+				Required:    true,
+			},
+			"after_rule": schema.StringAttribute{
+				Description: "Rule to position after (used when position is 'After')",
+				Optional:    true,
+			},
+			"before_rule": schema.StringAttribute{
+				Description: "Rule to position before (used when position is 'Before')",
+				Optional:    true,
+			},
+			"action": schema.StringAttribute{
+				Description: "Action (Accept, Reject, Drop)",
+				Required:    true,
+			},
+			"log_traffic": schema.StringAttribute{
+				Description: "Log traffic (Enable or Disable)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"skip_local_destined": schema.StringAttribute{
+				Description: "Skip local destined (Enable or Disable)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"source_zones": schema.ListAttribute{
+				Description: "List of source zones",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+			"destination_zones": schema.ListAttribute{
+				Description: "List of destination zones",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+			"schedule": schema.StringAttribute{
+				Description: "Schedule name",
+				Optional:    true,
+				Computed:    true,
+			},
+			"source_networks": schema.ListAttribute{
+				Description: "List of source networks",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"destination_networks": schema.ListAttribute{
+				Description: "List of destination networks",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"dscp_marking": schema.StringAttribute{
+				Description: "DSCP Marking value",
+				Optional:    true,
+				Computed:    true,
+			},
+			"web_filter": schema.StringAttribute{
+				Description: "Web Filter policy",
+				Optional:    true,
+				Computed:    true,
+			},
+			"web_category_base_qos_policy": schema.StringAttribute{
+				Description: "Web Category Base QoS Policy",
+				Optional:    true,
+				Computed:    true,
+			},
+			"block_quick_quic": schema.StringAttribute{
+				Description: "Block Quick/QUIC protocol (Enable or Disable)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"scan_virus": schema.StringAttribute{
+				Description: "Scan for viruses (Enable or Disable)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"zero_day_protection": schema.StringAttribute{
+				Description: "Zero Day Protection (Enable or Disable)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"proxy_mode": schema.StringAttribute{
+				Description: "Proxy Mode (Enable or Disable)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"decrypt_https": schema.StringAttribute{
+				Description: "Decrypt HTTPS (Enable or Disable)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"application_control": schema.StringAttribute{
+				Description: "Application Control policy",
+				Optional:    true,
+				Computed:    true,
+			},
+			"application_base_qos_policy": schema.StringAttribute{
+				Description: "Application Base QoS Policy",
+				Optional:    true,
+				Computed:    true,
+			},
+			"intrusion_prevention": schema.StringAttribute{
+				Description: "Intrusion Prevention policy",
+				Optional:    true,
+				Computed:    true,
+			},
+			"traffic_shapping_policy": schema.StringAttribute{
+				Description: "Traffic Shaping Policy",
+				Optional:    true,
+				Computed:    true,
+			},
+			"scan_smtp": schema.StringAttribute{
+				Description: "Scan SMTP (Enable or Disable)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"scan_smtps": schema.StringAttribute{
+				Description: "Scan SMTPS (Enable or Disable)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"scan_imap": schema.StringAttribute{
+				Description: "Scan IMAP (Enable or Disable)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"scan_imaps": schema.StringAttribute{
+				Description: "Scan IMAPS (Enable or Disable)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"scan_pop3": schema.StringAttribute{
+				Description: "Scan POP3 (Enable or Disable)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"scan_pop3s": schema.StringAttribute{
+				Description: "Scan POP3S (Enable or Disable)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"scan_ftp": schema.StringAttribute{
+				Description: "Scan FTP (Enable or Disable)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"source_security_heartbeat": schema.StringAttribute{
+				Description: "Source Security Heartbeat (Enable or Disable)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"minimum_source_hb_permitted": schema.StringAttribute{
+				Description: "Minimum Source HB Permitted",
+				Optional:    true,
+				Computed:    true,
+			},
+			"dest_security_heartbeat": schema.StringAttribute{
+				Description: "Destination Security Heartbeat (Enable or Disable)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"minimum_destination_hb_permitted": schema.StringAttribute{
+				Description: "Minimum Destination HB Permitted",
+				Optional:    true,
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// upgradeFirewallRuleStateV0 moves the network policy settings of a
+// version 0 state into the network_policy block, converts Enable/Disable
+// strings to booleans and lists to sets
+func upgradeFirewallRuleStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior firewallRuleResourceModelV0
+	diags := req.State.Get(ctx, &prior)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := firewallRuleResourceModel{
+		firewallRuleModel: firewallRuleModel{
+			Name:        prior.Name,
+			Description: prior.Description,
+			IPFamily:    prior.IPFamily,
+			Enabled:     enableBool(prior.Status.ValueString()),
+			Position:    prior.Position,
+			PolicyType:  prior.PolicyType,
+			AfterRule:   prior.AfterRule,
+			BeforeRule:  prior.BeforeRule,
+		},
+		Timeouts: prior.Timeouts,
+	}
+
+	// A rule read without a network policy had a null action
+	if !prior.Action.IsNull() {
+		state.NetworkPolicy = &networkPolicyModel{
+			Action:                        prior.Action,
+			LogTraffic:                    enableBool(prior.LogTraffic.ValueString()),
+			SkipLocalDestined:             enableBool(prior.SkipLocalDestined.ValueString()),
+			Schedule:                      prior.Schedule,
+			SourceZones:                   upgradeStringSet(prior.SourceZones),
+			DestinationZones:              upgradeStringSet(prior.DestinationZones),
+			SourceNetworks:                upgradeStringSet(prior.SourceNetworks),
+			DestinationNetworks:           upgradeStringSet(prior.DestinationNetworks),
+			DSCPMarking:                   prior.DSCPMarking,
+			WebFilter:                     prior.WebFilter,
+			WebCategoryBaseQoSPolicy:      prior.WebCategoryBaseQoSPolicy,
+			BlockQuickQuic:                enableBool(prior.BlockQuickQuic.ValueString()),
+			ScanVirus:                     enableBool(prior.ScanVirus.ValueString()),
+			ZeroDayProtection:             enableBool(prior.ZeroDayProtection.ValueString()),
+			ProxyMode:                     enableBool(prior.ProxyMode.ValueString()),
+			DecryptHTTPS:                  enableBool(prior.DecryptHTTPS.ValueString()),
+			ApplicationControl:            prior.ApplicationControl,
+			ApplicationBaseQoSPolicy:      prior.ApplicationBaseQoSPolicy,
+			IntrusionPrevention:           prior.IntrusionPrevention,
+			TrafficShapingPolicy:          prior.TrafficShappingPolicy,
+			ScanSMTP:                      enableBool(prior.ScanSMTP.ValueString()),
+			ScanSMTPS:                     enableBool(prior.ScanSMTPS.ValueString()),
+			ScanIMAP:                      enableBool(prior.ScanIMAP.ValueString()),
+			ScanIMAPS:                     enableBool(prior.ScanIMAPS.ValueString()),
+			ScanPOP3:                      enableBool(prior.ScanPOP3.ValueString()),
+			ScanPOP3S:                     enableBool(prior.ScanPOP3S.ValueString()),
+			ScanFTP:                       enableBool(prior.ScanFTP.ValueString()),
+			SourceSecurityHeartbeat:       enableBool(prior.SourceSecurityHeartbeat.ValueString()),
+			MinimumSourceHBPermitted:      prior.MinimumSourceHBPermitted,
+			DestSecurityHeartbeat:         enableBool(prior.DestSecurityHeartbeat.ValueString()),
+			MinimumDestinationHBPermitted: prior.MinimumDestinationHBPermitted,
+		}
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// upgradeStringSet converts a version 0 list to a set. A null list stays
+// null.
+func upgradeStringSet(list []types.String) []types.String {
+	if list == nil {
+		return nil
+	}
+	return stringSet(setStrings(list))
+}
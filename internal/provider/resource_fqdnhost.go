@@ -0,0 +1,324 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/fqdnhost"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var _ resource.Resource = &fqdnHostResource{}
+var _ resource.ResourceWithImportState = &fqdnHostResource{}
+var _ resource.ResourceWithValidateConfig = &fqdnHostResource{}
+
+// fqdnHostResource is the resource implementation
+type fqdnHostResource struct {
+	client *fqdnhost.Client
+}
+
+// fqdnHostModel maps the FQDN host attributes shared by the resource and data source
+type fqdnHostModel struct {
+	Name        types.String   `tfsdk:"name"`
+	Description types.String   `tfsdk:"description"`
+	FQDN        types.String   `tfsdk:"fqdn"`
+	HostGroups  []types.String `tfsdk:"host_groups"`
+}
+
+// fqdnHostResourceModel maps the resource schema data
+type fqdnHostResourceModel struct {
+	fqdnHostModel
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// NewFQDNHostResource creates a new resource
+func NewFQDNHostResource() resource.Resource {
+	return &fqdnHostResource{}
+}
+
+// Metadata returns the resource type name
+func (r *fqdnHostResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_fqdnhost"
+}
+
+// Schema defines the schema for the resource
+func (r *fqdnHostResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Sophos Firewall FQDN Host object",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "Name of the FQDN Host",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of the FQDN Host",
+				Optional:    true,
+				Computed:    true,
+			},
+			"fqdn": schema.StringAttribute{
+				Description: "Fully qualified domain name, a leading *. matches every subdomain",
+				Required:    true,
+			},
+			"host_groups": schema.ListAttribute{
+				Description: "List of FQDN Host Groups this FQDN Host belongs to",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *fqdnHostResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*SophosClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *SophosClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = fqdnhost.NewClient(client.BaseClient)
+}
+
+// ValidateConfig checks the syntax of the FQDN
+func (r *fqdnHostResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var fqdn types.String
+	diags := req.Config.GetAttribute(ctx, path.Root("fqdn"), &fqdn)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || fqdn.IsNull() || fqdn.IsUnknown() {
+		return
+	}
+
+	if !validFQDN(fqdn.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("fqdn"),
+			"Invalid FQDN",
+			fmt.Sprintf("Expected a domain name such as www.example.com, or *.example.com to match every subdomain, got: %q", fqdn.ValueString()),
+		)
+	}
+}
+
+// validFQDN reports whether the value is a domain name of at most 253
+// characters, optionally starting with the wildcard label *. Labels are
+// 1 to 63 letters, digits, hyphens and underscores, and neither start nor
+// end with a hyphen.
+func validFQDN(value string) bool {
+	name := strings.TrimPrefix(value, "*.")
+	if name == "" || len(value) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(name, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Create creates a new FQDN Host
+func (r *fqdnHostResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan fqdnHostResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	if err := r.client.CreateFQDNHost(ctx, modelToAPIFQDNHost(plan.fqdnHostModel)); err != nil {
+		addClientError(&resp.Diagnostics, "Error creating FQDN Host", err)
+		return
+	}
+
+	// Read the created host for the description the appliance filled in
+	created, err := r.client.ReadFQDNHost(ctx, plan.Name.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error reading created FQDN Host", err)
+		return
+	}
+	if created == nil {
+		resp.Diagnostics.AddError("Error after creation", "FQDN Host was not found after creation")
+		return
+	}
+
+	hostGroups := plan.HostGroups
+	plan.fqdnHostModel = apiToModelFQDNHost(*created)
+	plan.HostGroups = priorOrder(hostGroups, plan.HostGroups)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *fqdnHostResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state fqdnHostResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	host, err := r.client.ReadFQDNHost(ctx, state.Name.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error reading FQDN Host", err)
+		return
+	}
+
+	tflog.Debug(ctx, "Retrieved FQDN Host", map[string]interface{}{"object": fmt.Sprintf("%+v", host)})
+
+	if host == nil {
+		// Resource no longer exists
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	hostGroups := state.HostGroups
+	state.fqdnHostModel = apiToModelFQDNHost(*host)
+	state.HostGroups = priorOrder(hostGroups, state.HostGroups)
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state
+func (r *fqdnHostResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan fqdnHostResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	if err := r.client.UpdateFQDNHost(ctx, modelToAPIFQDNHost(plan.fqdnHostModel)); err != nil {
+		addClientError(&resp.Diagnostics, "Error updating FQDN Host", err)
+		return
+	}
+
+	updated, err := r.client.ReadFQDNHost(ctx, plan.Name.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error reading updated FQDN Host", err)
+		return
+	}
+	if updated == nil {
+		resp.Diagnostics.AddError("Error after update", "FQDN Host was not found after update")
+		return
+	}
+
+	hostGroups := plan.HostGroups
+	plan.fqdnHostModel = apiToModelFQDNHost(*updated)
+	plan.HostGroups = priorOrder(hostGroups, plan.HostGroups)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state
+func (r *fqdnHostResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state fqdnHostResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.DeleteFQDNHost(ctx, state.Name.ValueString())
+	// An object that is already gone counts as deleted
+	if err != nil && !isNotFound(err) {
+		addClientError(&resp.Diagnostics, "Error deleting FQDN Host", err)
+		return
+	}
+}
+
+// ImportState handles resource import
+func (r *fqdnHostResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import by name
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+// modelToAPIFQDNHost converts the Terraform model to the API structure.
+// Unset host groups are left out of the request.
+func modelToAPIFQDNHost(model fqdnHostModel) *fqdnhost.FQDNHost {
+	host := &fqdnhost.FQDNHost{
+		Name:        model.Name.ValueString(),
+		Description: model.Description.ValueString(),
+		FQDN:        model.FQDN.ValueString(),
+	}
+	if model.HostGroups != nil {
+		host.FQDNHostGroupList = &fqdnhost.FQDNHostGroupList{FQDNHostGroups: setStrings(model.HostGroups)}
+	}
+	return host
+}
+
+// apiToModelFQDNHost converts the API structure to the Terraform model.
+// Host groups are sorted, as the appliance does not keep their order.
+func apiToModelFQDNHost(host fqdnhost.FQDNHost) fqdnHostModel {
+	model := fqdnHostModel{
+		Name:        types.StringValue(host.Name),
+		Description: types.StringValue(host.Description),
+		FQDN:        types.StringValue(host.FQDN),
+		HostGroups:  []types.String{},
+	}
+	if host.FQDNHostGroupList != nil {
+		model.HostGroups = sortedList(host.FQDNHostGroupList.FQDNHostGroups)
+	}
+	return model
+}
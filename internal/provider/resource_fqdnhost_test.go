@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"math/rand"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFQDNHostResource(t *testing.T) {
+	server, providerConfig := testAccServer(t)
+	if err := server.Put(`<FQDNHostGroup><Name>saas</Name></FQDNHostGroup>`); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckEntityDestroyed(server, "FQDNHost", "office"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "sophosfirewall_fqdnhost" "test" {
+  name = "office"
+  fqdn = "*.office.com"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckEntityExists(server, "FQDNHost", "office"),
+					resource.TestCheckResourceAttr("sophosfirewall_fqdnhost.test", "fqdn", "*.office.com"),
+					resource.TestCheckResourceAttr("sophosfirewall_fqdnhost.test", "host_groups.#", "0"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "sophosfirewall_fqdnhost" "test" {
+  name        = "office"
+  description = "Microsoft 365"
+  fqdn        = "*.office.com"
+  host_groups = ["saas"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sophosfirewall_fqdnhost.test", "description", "Microsoft 365"),
+					resource.TestCheckResourceAttr("sophosfirewall_fqdnhost.test", "host_groups.#", "1"),
+					resource.TestCheckResourceAttr("sophosfirewall_fqdnhost.test", "host_groups.0", "saas"),
+				),
+			},
+			// Firewall rules can use FQDN hosts as networks
+			{
+				Config: providerConfig + `
+resource "sophosfirewall_fqdnhost" "test" {
+  name        = "office"
+  description = "Microsoft 365"
+  fqdn        = "*.office.com"
+  host_groups = ["saas"]
+}
+
+resource "sophosfirewall_firewallrule" "test" {
+  name        = "allow-office"
+  policy_type = "Network"
+  position    = "Bottom"
+
+  network_policy {
+    action               = "Accept"
+    source_zones         = ["LAN"]
+    destination_zones    = ["WAN"]
+    destination_networks = [sophosfirewall_fqdnhost.test.name]
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sophosfirewall_fqdnhost.test", "host_groups.0", "saas"),
+					resource.TestCheckTypeSetElemAttr("sophosfirewall_firewallrule.test", "network_policy.destination_networks.*", "office"),
+				),
+			},
+			{
+				ResourceName:                         "sophosfirewall_fqdnhost.test",
+				ImportState:                          true,
+				ImportStateId:                        "office",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+				ImportStateVerifyIgnore:              []string{"timeouts"},
+			},
+			{
+				Config: providerConfig + `
+resource "sophosfirewall_fqdnhost" "test" {
+  name = "office"
+  fqdn = "office.*.com"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid FQDN`),
+			},
+		},
+	})
+}
+
+func TestValidFQDN(t *testing.T) {
+	for value, want := range map[string]bool{
+		"example.com":                     true,
+		"*.example.com":                   true,
+		"_sip._tls.example.com":           true,
+		"localhost":                       true,
+		"xn--bcher-kva.example":           true,
+		"":                                false,
+		"*":                               false,
+		"*.":                              false,
+		"*.*.example.com":                 false,
+		"www.*.example.com":               false,
+		"example.com.":                    false,
+		"-example.com":                    false,
+		"example-.com":                    false,
+		"exa mple.com":                    false,
+		"http://example.com":              false,
+		strings.Repeat("a", 64) + ".com":  false,
+		strings.Repeat("a.", 126) + "com": false,
+	} {
+		if got := validFQDN(value); got != want {
+			t.Errorf("validFQDN(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestFQDNHostModelRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < roundTripRuns; i++ {
+		model := fqdnHostModel{
+			Name:        types.StringValue(randomName(r)),
+			Description: types.StringValue(randomText(r)),
+			FQDN:        types.StringValue(randomName(r)),
+			HostGroups:  sortedList(setStrings(randomList(r))),
+		}
+		if got := apiToModelFQDNHost(*modelToAPIFQDNHost(model)); !reflect.DeepEqual(got, model) {
+			t.Fatalf("round trip of\n%+v\n= %+v", model, got)
+		}
+	}
+}
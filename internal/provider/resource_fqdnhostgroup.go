@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/common"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/fqdnhost"
+)
+
+// fqdnHostGroupType describes the FQDN Host Group resource and data source
+var fqdnHostGroupType = groupType[fqdnhost.FQDNHostGroup]{
+	typeName: "_fqdnhostgroup",
+	label:    "FQDN Host Group",
+	members:  "host_list",
+	membersSchema: schema.ListAttribute{
+		Description: "List of FQDN Hosts this FQDN Host Group refers to",
+		Optional:    true,
+		ElementType: types.StringType,
+	},
+	client: func(base *common.BaseClient) groupClient[fqdnhost.FQDNHostGroup] {
+		client := fqdnhost.NewClient(base)
+		return groupClient[fqdnhost.FQDNHostGroup]{
+			create: client.CreateFQDNHostGroup,
+			read:   client.ReadFQDNHostGroup,
+			update: client.UpdateFQDNHostGroup,
+			delete: client.DeleteFQDNHostGroup,
+		}
+	},
+	toAPI:   modelToAPIFQDNHostGroup,
+	fromAPI: apiToModelFQDNHostGroup,
+}
+
+// NewFQDNHostGroupResource creates a new resource
+func NewFQDNHostGroupResource() resource.Resource {
+	return newGroupResource(fqdnHostGroupType)
+}
+
+// modelToAPIFQDNHostGroup converts the Terraform model to the API structure.
+// An unset host list is left out of the request.
+func modelToAPIFQDNHostGroup(model groupModel) *fqdnhost.FQDNHostGroup {
+	group := &fqdnhost.FQDNHostGroup{
+		Name:        model.Name.ValueString(),
+		Description: model.Description.ValueString(),
+	}
+	if model.Members != nil {
+		group.FQDNHostList = &fqdnhost.FQDNHostList{FQDNHosts: setStrings(model.Members)}
+	}
+	return group
+}
+
+// apiToModelFQDNHostGroup converts the API structure to the Terraform model.
+// Hosts are sorted, as the appliance does not keep their order.
+func apiToModelFQDNHostGroup(group fqdnhost.FQDNHostGroup) groupModel {
+	model := groupModel{
+		Name:        types.StringValue(group.Name),
+		Description: types.StringValue(group.Description),
+		Members:     []types.String{},
+	}
+	if group.FQDNHostList != nil {
+		model.Members = sortedList(group.FQDNHostList.FQDNHosts)
+	}
+	return model
+}
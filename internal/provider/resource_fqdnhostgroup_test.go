@@ -0,0 +1,27 @@
+package provider
+
+import "testing"
+
+// fqdnHostGroupTest describes the FQDN Host Group acceptance tests
+var fqdnHostGroupTest = groupTest{
+	resourceType: "sophosfirewall_fqdnhostgroup",
+	entityType:   "FQDNHostGroup",
+	members:      "host_list",
+	entities: []string{
+		`<FQDNHost><Name>office</Name><FQDN>*.office.com</FQDN></FQDNHost>`,
+		`<FQDNHost><Name>github</Name><FQDN>github.com</FQDN></FQDNHost>`,
+	},
+	name:         "saas",
+	description:  "SaaS applications",
+	created:      []string{"office"},
+	updated:      []string{"office", "github"},
+	ruleArgument: "destination_networks",
+}
+
+func TestAccFQDNHostGroupResource(t *testing.T) {
+	testAccGroupResource(t, fqdnHostGroupTest)
+}
+
+func TestFQDNHostGroupModelRoundTrip(t *testing.T) {
+	testGroupRoundTrip(t, fqdnHostGroupType, sortedList)
+}
@@ -0,0 +1,311 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/common"
+)
+
+// groupType describes a group object of the XML API: a name, a description
+// and the names of its members. G is the group structure of its client.
+type groupType[G any] struct {
+	// typeName is appended to the provider type name, e.g. "_service_group"
+	typeName string
+	// label names the object in descriptions and errors, e.g. "Service Group"
+	label string
+	// members is the name of the attribute holding the member names
+	members string
+	// membersSchema defines the members attribute of the resource
+	membersSchema schema.Attribute
+	// client returns the calls made for the group
+	client func(*common.BaseClient) groupClient[G]
+	// toAPI and fromAPI convert between the Terraform model and the API structure
+	toAPI   func(groupModel) *G
+	fromAPI func(G) groupModel
+}
+
+// groupClient holds the client calls for a group type
+type groupClient[G any] struct {
+	create func(context.Context, *G) error
+	read   func(context.Context, string) (*G, error)
+	update func(context.Context, *G) error
+	delete func(context.Context, string) error
+	// check, if set, reports members that cannot be added to the group,
+	// before the group is sent
+	check func(context.Context, []string, *diag.Diagnostics)
+}
+
+// groupModel maps the group attributes shared by the resource and data
+// source. It is read and written by path, as the name of the members
+// attribute differs between group types.
+type groupModel struct {
+	Name        types.String
+	Description types.String
+	Members     []types.String
+}
+
+// attributeGetter is a plan, state or configuration
+type attributeGetter interface {
+	GetAttribute(ctx context.Context, path path.Path, target interface{}) diag.Diagnostics
+}
+
+// get reads the group attributes from a plan, state or configuration
+func (t groupType[G]) get(ctx context.Context, data attributeGetter, diags *diag.Diagnostics) groupModel {
+	var model groupModel
+	diags.Append(data.GetAttribute(ctx, path.Root("name"), &model.Name)...)
+	diags.Append(data.GetAttribute(ctx, path.Root("description"), &model.Description)...)
+	diags.Append(data.GetAttribute(ctx, path.Root(t.members), &model.Members)...)
+	return model
+}
+
+// set writes the group attributes to the state
+func (t groupType[G]) set(ctx context.Context, state *tfsdk.State, model groupModel, diags *diag.Diagnostics) {
+	diags.Append(state.SetAttribute(ctx, path.Root("name"), model.Name)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("description"), model.Description)...)
+	diags.Append(state.SetAttribute(ctx, path.Root(t.members), model.Members)...)
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var _ resource.Resource = &groupResource[struct{}]{}
+var _ resource.ResourceWithImportState = &groupResource[struct{}]{}
+
+// groupResource is the resource implementation of a group type
+type groupResource[G any] struct {
+	groupType[G]
+	client groupClient[G]
+}
+
+// newGroupResource creates a new resource for the group type
+func newGroupResource[G any](t groupType[G]) resource.Resource {
+	return &groupResource[G]{groupType: t}
+}
+
+// Metadata returns the resource type name
+func (r *groupResource[G]) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + r.typeName
+}
+
+// Schema defines the schema for the resource
+func (r *groupResource[G]) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: fmt.Sprintf("Manages a Sophos Firewall %s object", r.label),
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: fmt.Sprintf("Name of the %s", r.label),
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: fmt.Sprintf("Description of the %s", r.label),
+				Optional:    true,
+				Computed:    true,
+			},
+			r.members: r.membersSchema,
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *groupResource[G]) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*SophosClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *SophosClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = r.groupType.client(client.BaseClient)
+}
+
+// Create creates a new group
+func (r *groupResource[G]) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	plan := r.get(ctx, req.Plan, &resp.Diagnostics)
+	var timeoutsValue timeouts.Value
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("timeouts"), &timeoutsValue)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := timeoutsValue.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	r.checkMembers(ctx, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.create(ctx, r.toAPI(plan)); err != nil {
+		addClientError(&resp.Diagnostics, "Error creating "+r.label, err)
+		return
+	}
+
+	// Read the created group for the description the appliance filled in
+	created, err := r.client.read(ctx, plan.Name.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error reading created "+r.label, err)
+		return
+	}
+	if created == nil {
+		resp.Diagnostics.AddError("Error after creation", r.label+" was not found after creation")
+		return
+	}
+
+	// Start from the plan, so the state keeps the configured timeouts
+	resp.State.Raw = req.Plan.Raw
+	r.setRead(ctx, &resp.State, plan.Members, *created, &resp.Diagnostics)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *groupResource[G]) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	state := r.get(ctx, req.State, &resp.Diagnostics)
+	var timeoutsValue timeouts.Value
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("timeouts"), &timeoutsValue)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := timeoutsValue.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	group, err := r.client.read(ctx, state.Name.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error reading "+r.label, err)
+		return
+	}
+
+	tflog.Debug(ctx, "Retrieved "+r.label, map[string]interface{}{"object": fmt.Sprintf("%+v", group)})
+
+	if group == nil {
+		// Resource no longer exists
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	r.setRead(ctx, &resp.State, state.Members, *group, &resp.Diagnostics)
+}
+
+// Update updates the resource and sets the updated Terraform state
+func (r *groupResource[G]) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	plan := r.get(ctx, req.Plan, &resp.Diagnostics)
+	var timeoutsValue timeouts.Value
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("timeouts"), &timeoutsValue)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := timeoutsValue.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	r.checkMembers(ctx, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.update(ctx, r.toAPI(plan)); err != nil {
+		addClientError(&resp.Diagnostics, "Error updating "+r.label, err)
+		return
+	}
+
+	updated, err := r.client.read(ctx, plan.Name.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error reading updated "+r.label, err)
+		return
+	}
+	if updated == nil {
+		resp.Diagnostics.AddError("Error after update", r.label+" was not found after update")
+		return
+	}
+
+	// Start from the plan, so the state keeps the configured timeouts
+	resp.State.Raw = req.Plan.Raw
+	r.setRead(ctx, &resp.State, plan.Members, *updated, &resp.Diagnostics)
+}
+
+// Delete deletes the resource and removes the Terraform state
+func (r *groupResource[G]) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	state := r.get(ctx, req.State, &resp.Diagnostics)
+	var timeoutsValue timeouts.Value
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("timeouts"), &timeoutsValue)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := timeoutsValue.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.delete(ctx, state.Name.ValueString())
+	// An object that is already gone counts as deleted
+	if err != nil && !isNotFound(err) {
+		addClientError(&resp.Diagnostics, "Error deleting "+r.label, err)
+		return
+	}
+}
+
+// ImportState handles resource import
+func (r *groupResource[G]) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import by name
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+// checkMembers reports the members of the group that cannot be added to it,
+// for group types that check them
+func (r *groupResource[G]) checkMembers(ctx context.Context, model groupModel, diags *diag.Diagnostics) {
+	if r.client.check != nil && len(model.Members) != 0 {
+		r.client.check(ctx, setStrings(model.Members), diags)
+	}
+}
+
+// setRead writes the group read from the appliance to the state. The members
+// keep the order of prior when both hold the same names.
+func (r *groupResource[G]) setRead(ctx context.Context, state *tfsdk.State, prior []types.String, group G, diags *diag.Diagnostics) {
+	model := r.fromAPI(group)
+	model.Members = priorOrder(prior, model.Members)
+	r.set(ctx, state, model, diags)
+}
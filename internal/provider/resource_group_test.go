@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// groupTest describes the acceptance tests shared by the group resources and
+// data sources
+type groupTest struct {
+	// resourceType is the type name of the resource and the data source
+	resourceType string
+	// entityType is the XML tag of the group
+	entityType string
+	// members is the name of the members attribute, membersSet whether it is a set
+	members    string
+	membersSet bool
+	// entities are put on the server before the test, e.g. the members
+	entities []string
+	// name and description of the group
+	name        string
+	description string
+	// created and updated are the members of the group when it is created
+	// and after it is updated
+	created []string
+	updated []string
+	// ruleArgument is the network_policy argument a firewall rule refers to
+	// the group in
+	ruleArgument string
+	// invalid are members the group type rejects with invalidError, for
+	// group types that check their members
+	invalid      []string
+	invalidError string
+}
+
+// hclStrings formats values as an HCL list of strings
+func hclStrings(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("%q", value)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// config returns the configuration of the group with the given members
+func (gt groupTest) config(members []string) string {
+	return fmt.Sprintf(`
+resource %q "test" {
+  name        = %q
+  description = %q
+  %-11s = %s
+}
+`, gt.resourceType, gt.name, gt.description, gt.members, hclStrings(members))
+}
+
+// checkMembers checks the members of the group at address, in the given
+// order unless the members are a set
+func (gt groupTest) checkMembers(address string, members []string) resource.TestCheckFunc {
+	checks := []resource.TestCheckFunc{
+		resource.TestCheckResourceAttr(address, gt.members+".#", fmt.Sprint(len(members))),
+	}
+	for i, member := range members {
+		if gt.membersSet {
+			checks = append(checks, resource.TestCheckTypeSetElemAttr(address, gt.members+".*", member))
+		} else {
+			checks = append(checks, resource.TestCheckResourceAttr(address, fmt.Sprintf("%s.%d", gt.members, i), member))
+		}
+	}
+	return resource.ComposeAggregateTestCheckFunc(checks...)
+}
+
+// testAccGroupResource creates and updates the group, refers to it from a
+// firewall rule and imports it
+func testAccGroupResource(t *testing.T, gt groupTest) {
+	server, providerConfig := testAccServer(t)
+	for _, entity := range gt.entities {
+		if err := server.Put(entity); err != nil {
+			t.Fatal(err)
+		}
+	}
+	address := gt.resourceType + ".test"
+
+	steps := []resource.TestStep{
+		{
+			Config: providerConfig + gt.config(gt.created),
+			Check: resource.ComposeAggregateTestCheckFunc(
+				testAccCheckEntityExists(server, gt.entityType, gt.name),
+				resource.TestCheckResourceAttr(address, "description", gt.description),
+				gt.checkMembers(address, gt.created),
+			),
+		},
+		// Firewall rules can use the group
+		{
+			Config: providerConfig + gt.config(gt.updated) + fmt.Sprintf(`
+resource "sophosfirewall_firewallrule" "test" {
+  name        = "allow-%s"
+  policy_type = "Network"
+  position    = "Bottom"
+
+  network_policy {
+    action            = "Accept"
+    source_zones      = ["LAN"]
+    destination_zones = ["WAN"]
+
+    %s = [%s.name]
+  }
+}
+`, gt.name, gt.ruleArgument, address),
+			Check: resource.ComposeAggregateTestCheckFunc(
+				gt.checkMembers(address, gt.updated),
+				resource.TestCheckTypeSetElemAttr("sophosfirewall_firewallrule.test", "network_policy."+gt.ruleArgument+".*", gt.name),
+			),
+		},
+		{
+			ResourceName:                         address,
+			ImportState:                          true,
+			ImportStateId:                        gt.name,
+			ImportStateVerify:                    true,
+			ImportStateVerifyIdentifierAttribute: "name",
+			ImportStateVerifyIgnore:              []string{"timeouts"},
+		},
+	}
+	if gt.invalid != nil {
+		steps = append(steps, resource.TestStep{
+			Config:      providerConfig + gt.config(gt.invalid),
+			ExpectError: regexp.MustCompile(gt.invalidError),
+		})
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckEntityDestroyed(server, gt.entityType, gt.name),
+		Steps:                    steps,
+	})
+}
+
+// testGroupRoundTrip converts random models of the group type to the API
+// structure and back. members returns the member names in the form they are
+// read from the appliance.
+func testGroupRoundTrip[G any](t *testing.T, gt groupType[G], members func([]string) []types.String) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < roundTripRuns; i++ {
+		model := groupModel{
+			Name:        types.StringValue(randomName(r)),
+			Description: types.StringValue(randomText(r)),
+			Members:     members(setStrings(randomList(r))),
+		}
+		if got := gt.fromAPI(*gt.toAPI(model)); !reflect.DeepEqual(got, model) {
+			t.Fatalf("round trip of\n%+v\n= %+v", model, got)
+		}
+	}
+}
@@ -4,14 +4,15 @@ package provider
 import (
 	"context"
 	"fmt"
-	"log"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/iphost"
 )
 
@@ -25,8 +26,8 @@ type ipHostResource struct {
 }
 
 
-// ipHostResourceModel maps the resource schema data
-type ipHostResourceModel struct {
+// ipHostModel maps the IP host attributes shared by the resource and data source
+type ipHostModel struct {
 	Name             types.String   `tfsdk:"name"`
 	IPFamily         types.String   `tfsdk:"ip_family"`
 	HostType         types.String   `tfsdk:"host_type"`
@@ -38,6 +39,12 @@ type ipHostResourceModel struct {
 	HostGroups       []types.String `tfsdk:"host_groups"`
 }
 
+// ipHostResourceModel maps the resource schema data
+type ipHostResourceModel struct {
+	ipHostModel
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
 
 
 // NewIPHostResource creates a new resource
@@ -51,7 +58,7 @@ func (r *ipHostResource) Metadata(_ context.Context, req resource.MetadataReques
 }
 
 // Schema defines the schema for the resource
-func (r *ipHostResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *ipHostResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a Sophos Firewall IP Host object",
 		Attributes: map[string]schema.Attribute{
@@ -97,6 +104,14 @@ func (r *ipHostResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				ElementType: types.StringType,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -128,33 +143,21 @@ func (r *ipHostResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	// Map from the terraform model to the API model
-	ipHost := &iphost.IPHost{
-		Name:            plan.Name.ValueString(),
-		IPFamily:          plan.IPFamily.ValueString(),
-		HostType:          plan.HostType.ValueString(),
-		IPAddress:         plan.IPAddress.ValueString(),
-		Subnet:            plan.Subnet.ValueString(),
-		StartIPAddress:    plan.StartIPAddress.ValueString(),
-		EndIPAddress:      plan.EndIPAddress.ValueString(),
-		ListOfIPAddresses: plan.ListOfIPAddresses.ValueString(),
-		TransactionID:     "", // Set empty
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
-	// Add host groups if specified
-	if len(plan.HostGroups) > 0 {
-		ipHost.HostGroupList = &iphost.HostGroupList{
-			HostGroups: make([]string, 0, len(plan.HostGroups)),
-		}
-		for _, hg := range plan.HostGroups {
-			ipHost.HostGroupList.HostGroups = append(ipHost.HostGroupList.HostGroups, hg.ValueString())
-		}
-	}
+	// Map from the terraform model to the API model
+	ipHost := modelToAPIIPHost(plan.ipHostModel)
 
 	// Create the IP Host
-	err := r.client.CreateIPHost(ipHost)
+	err := r.client.CreateIPHost(ctx, ipHost)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating IP Host", err.Error())
+		addClientError(&resp.Diagnostics, "Error creating IP Host", err)
 		return
 	}
 
@@ -173,14 +176,22 @@ func (r *ipHostResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Get the IP Host from the API
-	ipHost, err := r.client.ReadIPHost(state.Name.ValueString())
+	ipHost, err := r.client.ReadIPHost(ctx, state.Name.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading IP Host", err.Error())
+		addClientError(&resp.Diagnostics, "Error reading IP Host", err)
 		return
 	}
 
-	log.Printf("[DEBUG] Resource Read function - Retrieved IP Host: %+v", ipHost) // Log the retrieved object
+	tflog.Debug(ctx, "Retrieved IP Host", map[string]interface{}{"object": fmt.Sprintf("%+v", ipHost)})
 
 	if ipHost == nil {
 		// Resource no longer exists
@@ -188,61 +199,10 @@ func (r *ipHostResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	// Update state fields regardless of host type
-	state.Name = types.StringValue(ipHost.Name)
-	state.IPFamily = types.StringValue(ipHost.IPFamily)
-	state.HostType = types.StringValue(ipHost.HostType)
-		
-	// Handle field values based on the HostType
-	switch ipHost.HostType {
-	case "IP":
-		state.IPAddress = types.StringValue(ipHost.IPAddress)
-		// Explicitly set null for fields not applicable to this type
-		state.Subnet = types.StringNull()
-		state.StartIPAddress = types.StringNull()
-		state.EndIPAddress = types.StringNull()
-		state.ListOfIPAddresses = types.StringNull()
-	case "Network":
-		state.IPAddress = types.StringValue(ipHost.IPAddress)
-		state.Subnet = types.StringValue(ipHost.Subnet)
-		// Clear other fields
-		state.StartIPAddress = types.StringNull()
-		state.EndIPAddress = types.StringNull()
-		state.ListOfIPAddresses = types.StringNull()
-	case "IPRange":
-		state.StartIPAddress = types.StringValue(ipHost.StartIPAddress)
-		state.EndIPAddress = types.StringValue(ipHost.EndIPAddress)
-		// Clear other fields
-		state.IPAddress = types.StringNull()
-		state.Subnet = types.StringNull()
-		state.ListOfIPAddresses = types.StringNull()
-	case "IPList":
-		state.ListOfIPAddresses = types.StringValue(ipHost.ListOfIPAddresses)
-		// Clear other fields
-		state.IPAddress = types.StringNull()
-		state.Subnet = types.StringNull()
-		state.StartIPAddress = types.StringNull()
-		state.EndIPAddress = types.StringNull()
-	case "System Host":
-		// For system hosts, explicitly set all fields to null
-		state.IPAddress = types.StringNull()
-		state.Subnet = types.StringNull()
-		state.StartIPAddress = types.StringNull()
-		state.EndIPAddress = types.StringNull()
-		state.ListOfIPAddresses = types.StringNull()
-	}
-
-	// Handle host groups consistently
-	if ipHost.HostGroupList != nil && len(ipHost.HostGroupList.HostGroups) > 0 {
-		hostGroups := make([]types.String, 0, len(ipHost.HostGroupList.HostGroups))
-		for _, hg := range ipHost.HostGroupList.HostGroups {
-			hostGroups = append(hostGroups, types.StringValue(hg))
-		}
-		state.HostGroups = hostGroups
-	} else {
-		// Always initialize to empty slice when API returns no host groups
-		state.HostGroups = []types.String{}
-	}
+	// Update the Terraform state
+	hostGroups := state.HostGroups
+	state.ipHostModel = apiToModelIPHost(*ipHost)
+	state.HostGroups = priorOrder(hostGroups, state.HostGroups)
 
 	// Save the updated state
 	diags = resp.State.Set(ctx, &state)
@@ -258,33 +218,21 @@ func (r *ipHostResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	// Map from the terraform model to the API model
-	ipHost := &iphost.IPHost{
-		Name:            plan.Name.ValueString(),
-		IPFamily:          plan.IPFamily.ValueString(),
-		HostType:          plan.HostType.ValueString(),
-		IPAddress:         plan.IPAddress.ValueString(),
-		Subnet:            plan.Subnet.ValueString(),
-		StartIPAddress:    plan.StartIPAddress.ValueString(),
-		EndIPAddress:      plan.EndIPAddress.ValueString(),
-		ListOfIPAddresses: plan.ListOfIPAddresses.ValueString(),
-		TransactionID:     "",
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
-	// Add host groups if specified
-	if len(plan.HostGroups) > 0 {
-		ipHost.HostGroupList = &iphost.HostGroupList{
-			HostGroups: make([]string, 0, len(plan.HostGroups)),
-		}
-		for _, hg := range plan.HostGroups {
-			ipHost.HostGroupList.HostGroups = append(ipHost.HostGroupList.HostGroups, hg.ValueString())
-		}
-	}
+	// Map from the terraform model to the API model
+	ipHost := modelToAPIIPHost(plan.ipHostModel)
 
 	// Update the IP Host
-	err := r.client.UpdateIPHost(ipHost)
+	err := r.client.UpdateIPHost(ctx, ipHost)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating IP Host", err.Error())
+		addClientError(&resp.Diagnostics, "Error updating IP Host", err)
 		return
 	}
 
@@ -302,10 +250,19 @@ func (r *ipHostResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Delete the IP Host
-	err := r.client.DeleteIPHost(state.Name.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error deleting IP Host", err.Error())
+	err := r.client.DeleteIPHost(ctx, state.Name.ValueString())
+	// An object that is already gone counts as deleted
+	if err != nil && !isNotFound(err) {
+		addClientError(&resp.Diagnostics, "Error deleting IP Host", err)
 		return
 	}
 }
@@ -316,3 +273,68 @@ func (r *ipHostResource) ImportState(ctx context.Context, req resource.ImportSta
 	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
 }
 
+// modelToAPIIPHost converts the Terraform model to the API structure
+func modelToAPIIPHost(model ipHostModel) *iphost.IPHost {
+	ipHost := &iphost.IPHost{
+		Name:              model.Name.ValueString(),
+		IPFamily:          model.IPFamily.ValueString(),
+		HostType:          model.HostType.ValueString(),
+		IPAddress:         model.IPAddress.ValueString(),
+		Subnet:            model.Subnet.ValueString(),
+		StartIPAddress:    model.StartIPAddress.ValueString(),
+		EndIPAddress:      model.EndIPAddress.ValueString(),
+		ListOfIPAddresses: model.ListOfIPAddresses.ValueString(),
+		TransactionID:     "", // Set empty
+	}
+
+	// Add host groups if specified, an empty list leaves every group
+	if model.HostGroups != nil {
+		ipHost.HostGroupList = &iphost.HostGroupList{
+			HostGroups: make([]string, 0, len(model.HostGroups)),
+		}
+		for _, hg := range model.HostGroups {
+			ipHost.HostGroupList.HostGroups = append(ipHost.HostGroupList.HostGroups, hg.ValueString())
+		}
+	}
+
+	return ipHost
+}
+
+// apiToModelIPHost converts the API structure to the Terraform model. Only
+// the address attributes of the host type are set, the others are null.
+func apiToModelIPHost(ipHost iphost.IPHost) ipHostModel {
+	model := ipHostModel{
+		Name:              types.StringValue(ipHost.Name),
+		IPFamily:          types.StringValue(ipHost.IPFamily),
+		HostType:          types.StringValue(ipHost.HostType),
+		IPAddress:         types.StringNull(),
+		Subnet:            types.StringNull(),
+		StartIPAddress:    types.StringNull(),
+		EndIPAddress:      types.StringNull(),
+		ListOfIPAddresses: types.StringNull(),
+	}
+
+	// System hosts have no address attributes
+	switch ipHost.HostType {
+	case "IP":
+		model.IPAddress = types.StringValue(ipHost.IPAddress)
+	case "Network":
+		model.IPAddress = types.StringValue(ipHost.IPAddress)
+		model.Subnet = types.StringValue(ipHost.Subnet)
+	case "IPRange":
+		model.StartIPAddress = types.StringValue(ipHost.StartIPAddress)
+		model.EndIPAddress = types.StringValue(ipHost.EndIPAddress)
+	case "IPList":
+		model.ListOfIPAddresses = types.StringValue(ipHost.ListOfIPAddresses)
+	}
+
+	// Always initialize to empty slice when API returns no host groups
+	model.HostGroups = []types.String{}
+	if ipHost.HostGroupList != nil {
+		for _, hg := range ipHost.HostGroupList.HostGroups {
+			model.HostGroups = append(model.HostGroups, types.StringValue(hg))
+		}
+	}
+
+	return model
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccIPHostResource(t *testing.T) {
+	server, providerConfig := testAccServer(t)
+	for _, group := range []string{"servers", "monitored"} {
+		if err := server.Put(`<IPHostGroup><Name>` + group + `</Name><IPFamily>IPv4</IPFamily></IPHostGroup>`); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckEntityDestroyed(server, "IPHost", "web"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "sophosfirewall_iphost" "test" {
+  name        = "web"
+  ip_family   = "IPv4"
+  host_type   = "IP"
+  ip_address  = "10.0.0.1"
+  host_groups = ["servers"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckEntityExists(server, "IPHost", "web"),
+					resource.TestCheckResourceAttr("sophosfirewall_iphost.test", "ip_address", "10.0.0.1"),
+					resource.TestCheckResourceAttr("sophosfirewall_iphost.test", "host_groups.#", "1"),
+					resource.TestCheckResourceAttr("sophosfirewall_iphost.test", "host_groups.0", "servers"),
+				),
+			},
+			// The host groups keep the order of the configuration
+			{
+				Config: providerConfig + `
+resource "sophosfirewall_iphost" "test" {
+  name        = "web"
+  ip_family   = "IPv4"
+  host_type   = "IP"
+  ip_address  = "10.0.0.1"
+  host_groups = ["servers", "monitored"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sophosfirewall_iphost.test", "host_groups.#", "2"),
+					resource.TestCheckResourceAttr("sophosfirewall_iphost.test", "host_groups.0", "servers"),
+					resource.TestCheckResourceAttr("sophosfirewall_iphost.test", "host_groups.1", "monitored"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "sophosfirewall_iphost" "test" {
+  name        = "web"
+  ip_family   = "IPv4"
+  host_type   = "Network"
+  ip_address  = "10.0.0.0"
+  subnet      = "255.255.255.0"
+  host_groups = []
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sophosfirewall_iphost.test", "host_type", "Network"),
+					resource.TestCheckResourceAttr("sophosfirewall_iphost.test", "subnet", "255.255.255.0"),
+					resource.TestCheckResourceAttr("sophosfirewall_iphost.test", "host_groups.#", "0"),
+				),
+			},
+			{
+				ResourceName:                         "sophosfirewall_iphost.test",
+				ImportState:                          true,
+				ImportStateId:                        "web",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+			},
+		},
+	})
+}
+
+// randomIPHostModel returns a random model in the form it is read from the
+// appliance: the address attributes of its host type set and the others null
+func randomIPHostModel(r *rand.Rand) ipHostModel {
+	model := ipHostModel{
+		Name:              types.StringValue(randomName(r)),
+		IPFamily:          types.StringValue(randomText(r)),
+		IPAddress:         types.StringNull(),
+		Subnet:            types.StringNull(),
+		StartIPAddress:    types.StringNull(),
+		EndIPAddress:      types.StringNull(),
+		ListOfIPAddresses: types.StringNull(),
+		HostGroups:        []types.String{},
+	}
+	hostTypes := []string{"IP", "Network", "IPRange", "IPList", "System Host"}
+	hostType := hostTypes[r.Intn(len(hostTypes))]
+	model.HostType = types.StringValue(hostType)
+	switch hostType {
+	case "IP":
+		model.IPAddress = types.StringValue(randomText(r))
+	case "Network":
+		model.IPAddress = types.StringValue(randomText(r))
+		model.Subnet = types.StringValue(randomText(r))
+	case "IPRange":
+		model.StartIPAddress = types.StringValue(randomText(r))
+		model.EndIPAddress = types.StringValue(randomText(r))
+	case "IPList":
+		model.ListOfIPAddresses = types.StringValue(randomText(r))
+	}
+	model.HostGroups = append(model.HostGroups, randomList(r)...)
+	return model
+}
+
+func TestIPHostModelRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < roundTripRuns; i++ {
+		model := randomIPHostModel(r)
+		if got := apiToModelIPHost(*modelToAPIIPHost(model)); !reflect.DeepEqual(got, model) {
+			t.Fatalf("round trip of\n%+v\n= %+v", model, got)
+		}
+	}
+}
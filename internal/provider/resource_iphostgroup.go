@@ -4,16 +4,15 @@ package provider
 import (
 	"context"
 	"fmt"
-	"log"
-	"slices"
-
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/iphostgroup"
 )
 
@@ -27,14 +26,20 @@ type ipHostGroupResource struct {
 }
 
 
-// ipHostGroupResourceModel maps the resource schema data
-type ipHostGroupResourceModel struct {
+// ipHostGroupModel maps the IP host group attributes shared by the resource and data source
+type ipHostGroupModel struct {
 	Name             types.String   `tfsdk:"name"`
 	IPFamily         types.String   `tfsdk:"ip_family"`
 	Description      types.String   `tfsdk:"description"`
 	Hosts  			[]types.String 	`tfsdk:"host_list"`
 }
 
+// ipHostGroupResourceModel maps the resource schema data
+type ipHostGroupResourceModel struct {
+	ipHostGroupModel
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
 
 
 // NewipHostGroupResource creates a new resource
@@ -48,7 +53,7 @@ func (r *ipHostGroupResource) Metadata(_ context.Context, req resource.MetadataR
 }
 
 // Schema defines the schema for the resource
-func (r *ipHostGroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *ipHostGroupResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a Sophos Firewall IP Host Group object",
 		Attributes: map[string]schema.Attribute{
@@ -75,6 +80,14 @@ func (r *ipHostGroupResource) Schema(_ context.Context, _ resource.SchemaRequest
 				ElementType: types.StringType,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -106,28 +119,21 @@ func (r *ipHostGroupResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	// Map from the terraform model to the API model
-	ipHostGroup := &iphostgroup.IPHostGroup{
-		Name:            plan.Name.ValueString(),
-		IPFamily:        plan.IPFamily.ValueString(),
-		Description:     plan.Description.ValueString(),
-		TransactionID:     "", // Set empty
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
-	// Add host groups if specified
-	if len(plan.Hosts) > 0 {
-		ipHostGroup.HostList = &iphostgroup.HostList{
-			Hosts: make([]string, 0, len(plan.Hosts)),
-		}
-		for _, hg := range plan.Hosts {
-			ipHostGroup.HostList.Hosts = append(ipHostGroup.HostList.Hosts, hg.ValueString())
-		}
-	}
+	// Map from the terraform model to the API model
+	ipHostGroup := modelToAPIIPHostGroup(plan.ipHostGroupModel)
 
 	// Create the IP Host Group
-	err := r.client.CreateIPHostGroup(ipHostGroup)
+	err := r.client.CreateIPHostGroup(ctx, ipHostGroup)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating IP Host Group", err.Error())
+		addClientError(&resp.Diagnostics, "Error creating IP Host Group", err)
 		return
 	}
 
@@ -147,14 +153,22 @@ func (r *ipHostGroupResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Get the IP Host Group from the API
-	ipHostGroup, err := r.client.ReadIPHostGroup(state.Name.ValueString())
+	ipHostGroup, err := r.client.ReadIPHostGroup(ctx, state.Name.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading IP Host", err.Error())
+		addClientError(&resp.Diagnostics, "Error reading IP Host", err)
 		return
 	}
 
-	log.Printf("[DEBUG] Resource Read function - Retrieved IP Host Group: %+v", ipHostGroup) // Log the retrieved object
+	tflog.Debug(ctx, "Retrieved IP Host Group", map[string]interface{}{"object": fmt.Sprintf("%+v", ipHostGroup)})
 
 	if ipHostGroup == nil {
 		// Resource no longer exists
@@ -162,41 +176,17 @@ func (r *ipHostGroupResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	// Update state fields regardless of host type
-	state.Name = types.StringValue(ipHostGroup.Name)
-	state.IPFamily = types.StringValue(ipHostGroup.IPFamily)
-	state.Description = types.StringValue(ipHostGroup.Description)
-		
-	// Handle host groups consistently
-	if ipHostGroup.HostList != nil && len(ipHostGroup.HostList.Hosts) > 0 {
-		// Create a slice for sorting to ensure consistent order
-		hostStrings := make([]string, 0, len(ipHostGroup.HostList.Hosts))
-		for _, hg := range ipHostGroup.HostList.Hosts {
-			hostStrings = append(hostStrings, hg)
-		}
-		
-		// Sort the hosts alphabetically
-		slices.Sort(hostStrings)
-		
-		// Convert to types.String array
-		hosts := make([]types.String, 0, len(hostStrings))
-		for _, hg := range hostStrings {
-			hosts = append(hosts, types.StringValue(hg))
-		}
-		
-		// Use the correct field as defined in the struct tag
-		state.Hosts = hosts
-	} else {
-		// Always initialize to empty slice when API returns no host groups
-		state.Hosts = []types.String{}
-	}
+	// Update the Terraform state
+	hosts := state.Hosts
+	state.ipHostGroupModel = apiToModelIPHostGroup(*ipHostGroup)
+	state.Hosts = priorOrder(hosts, state.Hosts)
 
 	// Add debug logging to compare state
 	var currentHosts []string
 	for _, h := range state.Hosts {
 		currentHosts = append(currentHosts, h.ValueString())
 	}
-	log.Printf("[DEBUG] Updated state hosts: %v", currentHosts)
+	tflog.Debug(ctx, "Updated IP host group state", map[string]interface{}{"hosts": currentHosts})
 
 	// Save the updated state
 	diags = resp.State.Set(ctx, &state)
@@ -212,28 +202,21 @@ func (r *ipHostGroupResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	// Map from the terraform model to the API model
-	ipHostGroup := &iphostgroup.IPHostGroup{
-		Name:              plan.Name.ValueString(),
-		IPFamily:          plan.IPFamily.ValueString(),
-		Description:       plan.Description.ValueString(),
-		TransactionID:     "",
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
-	// Add host groups if specified
-	if len(plan.Hosts) > 0 {
-		ipHostGroup.HostList = &iphostgroup.HostList{
-			Hosts: make([]string, 0, len(plan.Hosts)),
-		}
-		for _, hg := range plan.Hosts {
-			ipHostGroup.HostList.Hosts = append(ipHostGroup.HostList.Hosts, hg.ValueString())
-		}
-	}
+	// Map from the terraform model to the API model
+	ipHostGroup := modelToAPIIPHostGroup(plan.ipHostGroupModel)
 
 	// Update the IP Host
-	err := r.client.UpdateIPHostGroup(ipHostGroup)
+	err := r.client.UpdateIPHostGroup(ctx, ipHostGroup)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating IP Host Group", err.Error())
+		addClientError(&resp.Diagnostics, "Error updating IP Host Group", err)
 		return
 	}
 
@@ -251,10 +234,19 @@ func (r *ipHostGroupResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Delete the IP Host
-	err := r.client.DeleteIPHostGroup(state.Name.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error deleting IP Host Group", err.Error())
+	err := r.client.DeleteIPHostGroup(ctx, state.Name.ValueString())
+	// An object that is already gone counts as deleted
+	if err != nil && !isNotFound(err) {
+		addClientError(&resp.Diagnostics, "Error deleting IP Host Group", err)
 		return
 	}
 }
@@ -265,3 +257,43 @@ func (r *ipHostGroupResource) ImportState(ctx context.Context, req resource.Impo
 	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
 }
 
+// modelToAPIIPHostGroup converts the Terraform model to the API structure
+func modelToAPIIPHostGroup(model ipHostGroupModel) *iphostgroup.IPHostGroup {
+	ipHostGroup := &iphostgroup.IPHostGroup{
+		Name:          model.Name.ValueString(),
+		IPFamily:      model.IPFamily.ValueString(),
+		Description:   model.Description.ValueString(),
+		TransactionID: "", // Set empty
+	}
+
+	// Add hosts if specified
+	if len(model.Hosts) > 0 {
+		ipHostGroup.HostList = &iphostgroup.HostList{
+			Hosts: make([]string, 0, len(model.Hosts)),
+		}
+		for _, host := range model.Hosts {
+			ipHostGroup.HostList.Hosts = append(ipHostGroup.HostList.Hosts, host.ValueString())
+		}
+	}
+
+	return ipHostGroup
+}
+
+// apiToModelIPHostGroup converts the API structure to the Terraform model
+func apiToModelIPHostGroup(ipHostGroup iphostgroup.IPHostGroup) ipHostGroupModel {
+	model := ipHostGroupModel{
+		Name:        types.StringValue(ipHostGroup.Name),
+		IPFamily:    types.StringValue(ipHostGroup.IPFamily),
+		Description: types.StringValue(ipHostGroup.Description),
+		// Always initialize to empty slice when API returns no hosts
+		Hosts: []types.String{},
+	}
+
+	if ipHostGroup.HostList != nil {
+		for _, host := range ipHostGroup.HostList.Hosts {
+			model.Hosts = append(model.Hosts, types.StringValue(host))
+		}
+	}
+
+	return model
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccIPHostGroupResource(t *testing.T) {
+	server, providerConfig := testAccServer(t)
+	for _, host := range []string{
+		`<IPHost><Name>app</Name><IPFamily>IPv4</IPFamily><HostType>IP</HostType><IPAddress>10.0.0.2</IPAddress></IPHost>`,
+		`<IPHost><Name>web</Name><IPFamily>IPv4</IPFamily><HostType>IP</HostType><IPAddress>10.0.0.1</IPAddress></IPHost>`,
+	} {
+		if err := server.Put(host); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckEntityDestroyed(server, "IPHostGroup", "servers"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "sophosfirewall_iphostgroup" "test" {
+  name        = "servers"
+  description = "Web servers"
+  ip_family   = "IPv4"
+  host_list   = ["web"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckEntityExists(server, "IPHostGroup", "servers"),
+					resource.TestCheckResourceAttr("sophosfirewall_iphostgroup.test", "host_list.#", "1"),
+					resource.TestCheckResourceAttr("sophosfirewall_iphostgroup.test", "host_list.0", "web"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "sophosfirewall_iphostgroup" "test" {
+  name        = "servers"
+  description = "Application servers"
+  ip_family   = "IPv4"
+  host_list   = ["web", "app"]
+}
+`,
+				// The hosts keep the order of the configuration
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sophosfirewall_iphostgroup.test", "description", "Application servers"),
+					resource.TestCheckResourceAttr("sophosfirewall_iphostgroup.test", "host_list.#", "2"),
+					resource.TestCheckResourceAttr("sophosfirewall_iphostgroup.test", "host_list.0", "web"),
+					resource.TestCheckResourceAttr("sophosfirewall_iphostgroup.test", "host_list.1", "app"),
+				),
+			},
+			{
+				ResourceName:                         "sophosfirewall_iphostgroup.test",
+				ImportState:                          true,
+				ImportStateId:                        "servers",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+			},
+		},
+	})
+}
+
+func TestIPHostGroupModelRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < roundTripRuns; i++ {
+		model := ipHostGroupModel{
+			Name:        types.StringValue(randomName(r)),
+			IPFamily:    types.StringValue(randomText(r)),
+			Description: types.StringValue(randomText(r)),
+			Hosts:       append([]types.String{}, randomList(r)...),
+		}
+		if got := apiToModelIPHostGroup(*modelToAPIIPHostGroup(model)); !reflect.DeepEqual(got, model) {
+			t.Fatalf("round trip of\n%+v\n= %+v", model, got)
+		}
+	}
+}
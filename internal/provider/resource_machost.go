@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -25,13 +26,20 @@ type macHostResource struct {
 	client *machost.Client
 }
 
+// macHostModel maps the MAC host attributes of the resource
+type macHostModel struct {
+	Name               types.String   `tfsdk:"name"`
+	Description        types.String   `tfsdk:"description"`
+	Type               types.String   `tfsdk:"type"`
+	MACAddress         types.String   `tfsdk:"mac_address"`
+	ListOfMACAddresses types.String   `tfsdk:"list_of_mac_addresses"`
+	HostGroups         []types.String `tfsdk:"host_groups"`
+}
+
 // macHostResourceModel maps the resource schema data
 type macHostResourceModel struct {
-	Name               types.String `tfsdk:"name"`
-	Description        types.String `tfsdk:"description"`
-	Type               types.String `tfsdk:"type"`
-	MACAddress         types.String `tfsdk:"mac_address"`
-	ListOfMACAddresses types.String `tfsdk:"list_of_mac_addresses"`
+	macHostModel
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 // NewMACHostResource creates a new resource
@@ -45,7 +53,7 @@ func (r *macHostResource) Metadata(_ context.Context, req resource.MetadataReque
 }
 
 // Schema defines the schema for the resource
-func (r *macHostResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *macHostResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a Sophos Firewall MAC Host object",
 		Attributes: map[string]schema.Attribute{
@@ -72,6 +80,19 @@ func (r *macHostResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Description: "Comma-separated list of MAC addresses for MACList type",
 				Optional:    true,
 			},
+			"host_groups": schema.ListAttribute{
+				Description: "List of MAC Host Groups this MAC Host belongs to",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -125,31 +146,24 @@ func (r *macHostResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Parse MAC addresses from the comma-separated string and ensure uniqueness
 	macAddresses := parseMACList(plan.ListOfMACAddresses.ValueString())
 
 	// Map from the terraform model to the API model
-	macHost := &machost.MACHost{
-		Name:              plan.Name.ValueString(),
-		Description:       plan.Description.ValueString(),
-		Type:              plan.Type.ValueString(),
-		TransactionID:     "", // Set empty
-	}
-	
-	// Set proper field based on type
-	if plan.Type.ValueString() == "MACAddress" {
-		macHost.MACAddress = plan.MACAddress.ValueString()
-		macHost.ListOfMACAddresses = nil
-	} else { // MACList type
-		macHost.MACAddress = ""
-		macHost.ListOfMACAddresses = macAddresses
-		macHost.Type = "MACLIST"
-	}
+	macHost := modelToAPIMACHost(plan.macHostModel)
 
 	// Create the MAC Host
-	err := r.client.CreateMACHost(macHost)
+	err := r.client.CreateMACHost(ctx, macHost)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating MAC Host", err.Error())
+		addClientError(&resp.Diagnostics, "Error creating MAC Host", err)
 		return
 	}
 
@@ -173,10 +187,18 @@ func (r *macHostResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Get the MAC Host from the API
-	macHost, err := r.client.ReadMACHost(state.Name.ValueString())
+	macHost, err := r.client.ReadMACHost(ctx, state.Name.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading MAC Host", err.Error())
+		addClientError(&resp.Diagnostics, "Error reading MAC Host", err)
 		return
 	}
 
@@ -187,52 +209,9 @@ func (r *macHostResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 
 	// Update state with values from API
-	state.Name = types.StringValue(macHost.Name)
-	
-	if macHost.Description != "" {
-		state.Description = types.StringValue(macHost.Description)
-	} else {
-		state.Description = types.StringNull()
-	}
-	
-	state.Type = types.StringValue(macHost.Type)
-	
-	// Handle MAC address fields based on type (case-insensitive comparison)
-	typeUpper:= strings.ToUpper(macHost.Type)
-	
-	// Set the exact type value from the API
-	state.Type = types.StringValue(macHost.Type)
-	
-	if typeUpper == "MACADDRESS" {
-		if macHost.MACAddress != "" {
-			state.MACAddress = types.StringValue(macHost.MACAddress)
-		} else {
-			state.MACAddress = types.StringNull()
-		}
-		state.ListOfMACAddresses = types.StringNull()
-	} else if typeUpper == "MACLIST" {
-		state.MACAddress = types.StringNull()
-		
-		// Convert MAC addresses back to comma-separated string
-		if macHost.ListOfMACAddresses != nil && len(macHost.ListOfMACAddresses) > 0 {
-			// Only include unique MAC addresses
-			uniqueMACs := make(map[string]bool)
-			var uniqueList []string
-			
-			for _, mac := range macHost.ListOfMACAddresses {
-				if !uniqueMACs[mac] {
-					uniqueMACs[mac] = true
-					uniqueList = append(uniqueList, mac)
-				}
-			}
-			
-			state.ListOfMACAddresses = types.StringValue(strings.Join(uniqueList, ","))
-		} else {
-			// Always set an empty string instead of null for MACLIST type to avoid unknown values
-			state.ListOfMACAddresses = types.StringValue("")
-		}
-	}
-
+	hostGroups := state.HostGroups
+	state.macHostModel = apiToModelMACHost(*macHost)
+	state.HostGroups = priorOrder(hostGroups, state.HostGroups)
 
 	// Save the updated state
 	diags = resp.State.Set(ctx, &state)
@@ -248,33 +227,28 @@ func (r *macHostResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Parse MAC addresses from the comma-separated string and ensure uniqueness
 	macAddresses := parseMACList(plan.ListOfMACAddresses.ValueString())
 
 	// Map from the terraform model to the API model
-	macHost := &machost.MACHost{
-		Name:           plan.Name.ValueString(),
-		Description:    plan.Description.ValueString(),
-		Type:           plan.Type.ValueString(),
-		TransactionID:  "", // Set empty
-	}
-	
+	macHost := modelToAPIMACHost(plan.macHostModel)
+
 	// Set proper field based on type (case-insensitive comparison)
 	typeUpper:= strings.ToUpper(plan.Type.ValueString())
 
 	if typeUpper == "MACADDRESS" {
-		macHost.MACAddress = plan.MACAddress.ValueString()
-		macHost.ListOfMACAddresses = nil
-		macHost.Type = "MACAddress" // Ensure consistent type naming
-		
 		// Update the plan with standardized type and ensure ListOfMACAddresses is null
 		plan.Type = types.StringValue("MACAddress")
 		plan.ListOfMACAddresses = types.StringNull()
 	} else if typeUpper == "MACLIST" {
-		macHost.MACAddress = ""
-		macHost.ListOfMACAddresses = macAddresses
-		macHost.Type = "MACLIST" // Ensure consistent type naming
-		
 		// Update the plan with standardized type and ensure MACAddress is null
 		plan.Type = types.StringValue("MACLIST")
 		plan.MACAddress = types.StringNull()
@@ -287,9 +261,9 @@ func (r *macHostResource) Update(ctx context.Context, req resource.UpdateRequest
 
 
 	// Update the MAC Host
-	err := r.client.UpdateMACHost(macHost)
+	err := r.client.UpdateMACHost(ctx, macHost)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating MAC Host", err.Error())
+		addClientError(&resp.Diagnostics, "Error updating MAC Host", err)
 		return
 	}
 
@@ -307,10 +281,19 @@ func (r *macHostResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Delete the MAC Host
-	err := r.client.DeleteMACHost(state.Name.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error deleting MAC Host", err.Error())
+	err := r.client.DeleteMACHost(ctx, state.Name.ValueString())
+	// An object that is already gone counts as deleted
+	if err != nil && !isNotFound(err) {
+		addClientError(&resp.Diagnostics, "Error deleting MAC Host", err)
 		return
 	}
 }
@@ -319,4 +302,69 @@ func (r *macHostResource) Delete(ctx context.Context, req resource.DeleteRequest
 func (r *macHostResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Import by name
 	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
-}
\ No newline at end of file
+}
+
+// modelToAPIMACHost converts the Terraform model to the API structure. Any
+// type other than MACAddress is sent as MACLIST. Unset host groups are left
+// out of the request.
+func modelToAPIMACHost(model macHostModel) *machost.MACHost {
+	macHost := &machost.MACHost{
+		Name:             model.Name.ValueString(),
+		Description:      model.Description.ValueString(),
+		TransactionID:    "", // Set empty
+	}
+
+	// Set proper field based on type (case-insensitive comparison)
+	if strings.EqualFold(model.Type.ValueString(), "MACAddress") {
+		macHost.Type = "MACAddress"
+		macHost.MACAddress = model.MACAddress.ValueString()
+	} else {
+		macHost.Type = "MACLIST"
+		macHost.ListOfMACAddresses = parseMACList(model.ListOfMACAddresses.ValueString())
+	}
+	if model.HostGroups != nil {
+		macHost.MACHostGroupList = &machost.MACHostGroupList{MACHostGroups: setStrings(model.HostGroups)}
+	}
+
+	return macHost
+}
+
+// apiToModelMACHost converts the API structure to the Terraform model.
+// Host groups are sorted, as the appliance does not keep their order.
+func apiToModelMACHost(macHost machost.MACHost) macHostModel {
+	model := macHostModel{
+		Name:               types.StringValue(macHost.Name),
+		Description:        types.StringNull(),
+		Type:               types.StringValue(macHost.Type),
+		MACAddress:         types.StringNull(),
+		ListOfMACAddresses: types.StringNull(),
+		HostGroups:         []types.String{},
+	}
+	if macHost.Description != "" {
+		model.Description = types.StringValue(macHost.Description)
+	}
+	if macHost.MACHostGroupList != nil {
+		model.HostGroups = sortedList(macHost.MACHostGroupList.MACHostGroups)
+	}
+
+	switch strings.ToUpper(macHost.Type) {
+	case "MACADDRESS":
+		if macHost.MACAddress != "" {
+			model.MACAddress = types.StringValue(macHost.MACAddress)
+		}
+	case "MACLIST":
+		// Only include unique MAC addresses
+		uniqueMACs := make(map[string]bool)
+		uniqueList := []string{}
+		for _, mac := range macHost.ListOfMACAddresses {
+			if !uniqueMACs[mac] {
+				uniqueMACs[mac] = true
+				uniqueList = append(uniqueList, mac)
+			}
+		}
+		// Always set an empty string instead of null for MACLIST type to avoid unknown values
+		model.ListOfMACAddresses = types.StringValue(strings.Join(uniqueList, ","))
+	}
+
+	return model
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/testserver"
+)
+
+// testAccCheckMACHostGroupMembers verifies the server lists the hosts in
+// the MAC host group
+func testAccCheckMACHostGroupMembers(server *testserver.Server, group string, hosts ...string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		entity, ok := server.Entity("MACHostGroup", group)
+		if !ok {
+			return fmt.Errorf("MAC host group %q not found on the server", group)
+		}
+		for _, host := range hosts {
+			if !strings.Contains(entity, "<MACHost>"+host+"</MACHost>") {
+				return fmt.Errorf("MAC host group %s = %s, want member %s", group, entity, host)
+			}
+		}
+		return nil
+	}
+}
+
+func TestAccMACHostResource(t *testing.T) {
+	server, providerConfig := testAccServer(t)
+	if err := server.Put(`<MACHostGroup><Name>printers</Name></MACHostGroup>`); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckEntityDestroyed(server, "MACHost", "printer"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "sophosfirewall_machost" "test" {
+  name        = "printer"
+  description = "Office printer"
+  type        = "MACAddress"
+  mac_address = "00:11:22:33:44:55"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckEntityExists(server, "MACHost", "printer"),
+					resource.TestCheckResourceAttr("sophosfirewall_machost.test", "mac_address", "00:11:22:33:44:55"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "sophosfirewall_machost" "test" {
+  name                  = "printer"
+  description           = "Office printers"
+  type                  = "MACLIST"
+  list_of_mac_addresses = "00:11:22:33:44:55,00:11:22:33:44:66"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sophosfirewall_machost.test", "type", "MACLIST"),
+					resource.TestCheckResourceAttr("sophosfirewall_machost.test", "list_of_mac_addresses", "00:11:22:33:44:55,00:11:22:33:44:66"),
+					resource.TestCheckNoResourceAttr("sophosfirewall_machost.test", "mac_address"),
+					resource.TestCheckResourceAttr("sophosfirewall_machost.test", "host_groups.#", "0"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "sophosfirewall_machost" "test" {
+  name                  = "printer"
+  description           = "Office printers"
+  type                  = "MACLIST"
+  list_of_mac_addresses = "00:11:22:33:44:55,00:11:22:33:44:66"
+  host_groups           = ["printers"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sophosfirewall_machost.test", "host_groups.#", "1"),
+					resource.TestCheckResourceAttr("sophosfirewall_machost.test", "host_groups.0", "printers"),
+					testAccCheckMACHostGroupMembers(server, "printers", "printer"),
+				),
+			},
+			{
+				ResourceName:                         "sophosfirewall_machost.test",
+				ImportState:                          true,
+				ImportStateId:                        "printer",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+			},
+		},
+	})
+}
+
+// randomMACHostModel returns a random model in the form it is read from the
+// appliance: a null description instead of an empty one, and a list of
+// distinct MAC addresses without surrounding spaces
+func randomMACHostModel(r *rand.Rand) macHostModel {
+	model := macHostModel{
+		Name:               types.StringValue(randomName(r)),
+		Description:        randomOptional(r),
+		MACAddress:         types.StringNull(),
+		ListOfMACAddresses: types.StringNull(),
+	}
+	model.HostGroups = sortedList(setStrings(randomList(r)))
+	if r.Intn(2) == 0 {
+		model.Type = types.StringValue("MACAddress")
+		model.MACAddress = randomOptional(r)
+		return model
+	}
+
+	model.Type = types.StringValue("MACLIST")
+	var macs []string
+	seen := map[string]bool{}
+	for _, mac := range randomList(r) {
+		value := strings.TrimSpace(strings.ReplaceAll(mac.ValueString(), ",", ""))
+		if value != "" && !seen[value] {
+			seen[value] = true
+			macs = append(macs, value)
+		}
+	}
+	model.ListOfMACAddresses = types.StringValue(strings.Join(macs, ","))
+	return model
+}
+
+func TestMACHostModelRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < roundTripRuns; i++ {
+		model := randomMACHostModel(r)
+		if got := apiToModelMACHost(*modelToAPIMACHost(model)); !reflect.DeepEqual(got, model) {
+			t.Fatalf("round trip of\n%+v\n= %+v", model, got)
+		}
+	}
+}
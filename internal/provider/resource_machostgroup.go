@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/common"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/machost"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/machostgroup"
+)
+
+// macHostGroupType describes the MAC Host Group resource and data source
+var macHostGroupType = groupType[machostgroup.MACHostGroup]{
+	typeName: "_machostgroup",
+	label:    "MAC Host Group",
+	members:  "host_list",
+	membersSchema: schema.ListAttribute{
+		Description: "List of MAC Hosts this MAC Host Group refers to",
+		Optional:    true,
+		ElementType: types.StringType,
+	},
+	client: func(base *common.BaseClient) groupClient[machostgroup.MACHostGroup] {
+		client, hosts := machostgroup.NewClient(base), machost.NewClient(base)
+		return groupClient[machostgroup.MACHostGroup]{
+			create: client.CreateMACHostGroup,
+			read:   client.ReadMACHostGroup,
+			update: client.UpdateMACHostGroup,
+			delete: client.DeleteMACHostGroup,
+			check: func(ctx context.Context, members []string, diags *diag.Diagnostics) {
+				checkMACHosts(ctx, hosts, members, diags)
+			},
+		}
+	},
+	toAPI:   modelToAPIMACHostGroup,
+	fromAPI: apiToModelMACHostGroup,
+}
+
+// NewMACHostGroupResource creates a new resource
+func NewMACHostGroupResource() resource.Resource {
+	return newGroupResource(macHostGroupType)
+}
+
+// checkMACHosts reports the members of the group that are not MAC hosts on
+// the firewall
+func checkMACHosts(ctx context.Context, client *machost.Client, members []string, diags *diag.Diagnostics) {
+	hosts, err := client.ListMACHosts(ctx)
+	if err != nil {
+		addClientError(diags, "Error listing MAC Hosts", err)
+		return
+	}
+	existing := make(map[string]bool, len(hosts))
+	for _, host := range hosts {
+		existing[host.Name] = true
+	}
+
+	for _, member := range members {
+		if !existing[member] {
+			diags.AddAttributeError(
+				path.Root("host_list"),
+				"MAC Host Not Found",
+				fmt.Sprintf("MAC Host %s does not exist, so it cannot be added to the group.", member),
+			)
+		}
+	}
+}
+
+// modelToAPIMACHostGroup converts the Terraform model to the API structure.
+// An unset host list is left out of the request.
+func modelToAPIMACHostGroup(model groupModel) *machostgroup.MACHostGroup {
+	group := &machostgroup.MACHostGroup{
+		Name:        model.Name.ValueString(),
+		Description: model.Description.ValueString(),
+	}
+	if model.Members != nil {
+		group.MACHostList = &machostgroup.MACHostList{MACHosts: setStrings(model.Members)}
+	}
+	return group
+}
+
+// apiToModelMACHostGroup converts the API structure to the Terraform model.
+// Hosts are sorted, as the appliance does not keep their order.
+func apiToModelMACHostGroup(group machostgroup.MACHostGroup) groupModel {
+	model := groupModel{
+		Name:        types.StringValue(group.Name),
+		Description: types.StringValue(group.Description),
+		Members:     []types.String{},
+	}
+	if group.MACHostList != nil {
+		model.Members = sortedList(group.MACHostList.MACHosts)
+	}
+	return model
+}
@@ -0,0 +1,29 @@
+package provider
+
+import "testing"
+
+// macHostGroupTest describes the MAC Host Group acceptance tests
+var macHostGroupTest = groupTest{
+	resourceType: "sophosfirewall_machostgroup",
+	entityType:   "MACHostGroup",
+	members:      "host_list",
+	entities: []string{
+		`<MACHost><Name>printer1</Name><Type>MACAddress</Type><MACAddress>00:16:3e:00:00:01</MACAddress></MACHost>`,
+		`<MACHost><Name>printer2</Name><Type>MACAddress</Type><MACAddress>00:16:3e:00:00:02</MACAddress></MACHost>`,
+	},
+	name:         "printers",
+	description:  "Office printers",
+	created:      []string{"printer1"},
+	updated:      []string{"printer2", "printer1"},
+	ruleArgument: "source_networks",
+	invalid:      []string{"printer1", "scanner"},
+	invalidError: `MAC Host scanner does not exist`,
+}
+
+func TestAccMACHostGroupResource(t *testing.T) {
+	testAccGroupResource(t, macHostGroupTest)
+}
+
+func TestMACHostGroupModelRoundTrip(t *testing.T) {
+	testGroupRoundTrip(t, macHostGroupType, sortedList)
+}
@@ -0,0 +1,494 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/service"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var _ resource.Resource = &serviceResource{}
+var _ resource.ResourceWithImportState = &serviceResource{}
+var _ resource.ResourceWithValidateConfig = &serviceResource{}
+
+// serviceResource is the resource implementation
+type serviceResource struct {
+	client *service.Client
+}
+
+// serviceModel maps the service attributes shared by the resource and data source
+type serviceModel struct {
+	Name        types.String         `tfsdk:"name"`
+	Description types.String         `tfsdk:"description"`
+	Type        types.String         `tfsdk:"type"`
+	Details     []serviceDetailModel `tfsdk:"service_details"`
+}
+
+// serviceDetailModel maps one protocol and port entry of a service
+type serviceDetailModel struct {
+	Protocol        types.String `tfsdk:"protocol"`
+	SourcePort      types.String `tfsdk:"source_port"`
+	DestinationPort types.String `tfsdk:"destination_port"`
+	ProtocolName    types.String `tfsdk:"protocol_name"`
+	ICMPType        types.String `tfsdk:"icmp_type"`
+	ICMPCode        types.String `tfsdk:"icmp_code"`
+}
+
+// serviceResourceModel maps the resource schema data
+type serviceResourceModel struct {
+	serviceModel
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Values the appliance uses for the attributes a service detail leaves out
+const (
+	allPorts    = "1:65535"
+	anyICMPCode = "Any Code"
+)
+
+// serviceTypeAttributes maps the service types to the service_details
+// attributes they require, and the optional ones they allow
+var serviceTypeAttributes = map[string]struct{ required, optional []string }{
+	service.TypeTCPOrUDP: {required: []string{"protocol", "destination_port"}, optional: []string{"source_port"}},
+	service.TypeIP:       {required: []string{"protocol_name"}},
+	service.TypeICMP:     {required: []string{"icmp_type"}, optional: []string{"icmp_code"}},
+	service.TypeICMPv6:   {required: []string{"icmp_type"}, optional: []string{"icmp_code"}},
+}
+
+// NewServiceResource creates a new resource
+func NewServiceResource() resource.Resource {
+	return &serviceResource{}
+}
+
+// Metadata returns the resource type name
+func (r *serviceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service"
+}
+
+// Schema defines the schema for the resource
+func (r *serviceResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Sophos Firewall Service object",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "Name of the Service",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of the Service",
+				Optional:    true,
+				Computed:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Service Type (TCPorUDP, IP, ICMP, ICMPv6)",
+				Required:    true,
+			},
+			"service_details": schema.ListNestedAttribute{
+				Description: "Protocols and ports the Service matches",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"protocol": schema.StringAttribute{
+							Description: "Protocol (TCP or UDP) for TCPorUDP services",
+							Optional:    true,
+						},
+						"source_port": schema.StringAttribute{
+							Description: "Source port or port range low:high for TCPorUDP services, defaults to " + allPorts,
+							Optional:    true,
+							Computed:    true,
+						},
+						"destination_port": schema.StringAttribute{
+							Description: "Destination port or port range low:high for TCPorUDP services",
+							Optional:    true,
+						},
+						"protocol_name": schema.StringAttribute{
+							Description: "IP protocol name (for example GRE or ESP) for IP services",
+							Optional:    true,
+						},
+						"icmp_type": schema.StringAttribute{
+							Description: "ICMP type (for example Echo Request) for ICMP and ICMPv6 services",
+							Optional:    true,
+						},
+						"icmp_code": schema.StringAttribute{
+							Description: "ICMP code for ICMP and ICMPv6 services, defaults to " + anyICMPCode,
+							Optional:    true,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *serviceResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*SophosClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *SophosClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = service.NewClient(client.BaseClient)
+}
+
+// ValidateConfig requires the service_details attributes of the service
+// type, rejects those of other types and checks the ports
+func (r *serviceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var serviceType types.String
+	diags := req.Config.GetAttribute(ctx, path.Root("type"), &serviceType)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || serviceType.IsNull() || serviceType.IsUnknown() {
+		return
+	}
+
+	attributes, ok := serviceTypeAttributes[serviceType.ValueString()]
+	if !ok {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("type"),
+			"Invalid Service Type",
+			fmt.Sprintf("Expected type TCPorUDP, IP, ICMP or ICMPv6, got: %q", serviceType.ValueString()),
+		)
+		return
+	}
+
+	var details types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("service_details"), &details)...)
+	if resp.Diagnostics.HasError() || details.IsNull() || details.IsUnknown() {
+		return
+	}
+	if len(details.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("service_details"),
+			"Missing Service Details",
+			"A service requires at least one service_details entry.",
+		)
+		return
+	}
+
+	for i, element := range details.Elements() {
+		detail, ok := element.(types.Object)
+		if !ok || detail.IsNull() || detail.IsUnknown() {
+			continue
+		}
+		detailPath := path.Root("service_details").AtListIndex(i)
+		for name, value := range detail.Attributes() {
+			switch {
+			case value.IsNull() && slices.Contains(attributes.required, name):
+				resp.Diagnostics.AddAttributeError(
+					detailPath.AtName(name),
+					"Missing Required Attribute",
+					fmt.Sprintf("A %s service requires the %s attribute.", serviceType.ValueString(), name),
+				)
+			case !value.IsNull() && !slices.Contains(attributes.required, name) && !slices.Contains(attributes.optional, name):
+				resp.Diagnostics.AddAttributeError(
+					detailPath.AtName(name),
+					"Unexpected Attribute",
+					fmt.Sprintf("A %s service cannot have the %s attribute.", serviceType.ValueString(), name),
+				)
+			}
+		}
+
+		if serviceType.ValueString() != service.TypeTCPOrUDP {
+			continue
+		}
+		if protocol, ok := detail.Attributes()["protocol"].(types.String); ok && !protocol.IsNull() && !protocol.IsUnknown() &&
+			protocol.ValueString() != "TCP" && protocol.ValueString() != "UDP" {
+			resp.Diagnostics.AddAttributeError(
+				detailPath.AtName("protocol"),
+				"Invalid Protocol",
+				fmt.Sprintf("Expected protocol TCP or UDP, got: %q", protocol.ValueString()),
+			)
+		}
+		for _, name := range []string{"source_port", "destination_port"} {
+			port, ok := detail.Attributes()[name].(types.String)
+			if !ok || port.IsNull() || port.IsUnknown() || validPortRange(port.ValueString()) {
+				continue
+			}
+			resp.Diagnostics.AddAttributeError(
+				detailPath.AtName(name),
+				"Invalid Port",
+				fmt.Sprintf("Expected a port or a port range low:high between 1 and 65535, got: %q", port.ValueString()),
+			)
+		}
+	}
+}
+
+// validPortRange reports whether the value is a port or a port range
+// low:high, with ports between 1 and 65535
+func validPortRange(value string) bool {
+	low, high, isRange := strings.Cut(value, ":")
+	if !isRange {
+		high = low
+	}
+	lowPort, ok := parsePort(low)
+	if !ok {
+		return false
+	}
+	highPort, ok := parsePort(high)
+	return ok && lowPort <= highPort
+}
+
+// parsePort parses a port between 1 and 65535 given in decimal digits
+func parsePort(value string) (int, bool) {
+	if value == "" || strings.TrimLeft(value, "0123456789") != "" {
+		return 0, false
+	}
+	port, err := strconv.Atoi(value)
+	return port, err == nil && port >= 1 && port <= 65535
+}
+
+// Create creates a new Service
+func (r *serviceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan serviceResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	if err := r.client.CreateService(ctx, modelToAPIService(plan.serviceModel)); err != nil {
+		addClientError(&resp.Diagnostics, "Error creating Service", err)
+		return
+	}
+
+	// Read the created service for the ports and codes the appliance filled in
+	created, err := r.client.ReadService(ctx, plan.Name.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error reading created Service", err)
+		return
+	}
+	if created == nil {
+		resp.Diagnostics.AddError("Error after creation", "Service was not found after creation")
+		return
+	}
+
+	plan.serviceModel = apiToModelService(*created)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *serviceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state serviceResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	svc, err := r.client.ReadService(ctx, state.Name.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error reading Service", err)
+		return
+	}
+
+	tflog.Debug(ctx, "Retrieved Service", map[string]interface{}{"object": fmt.Sprintf("%+v", svc)})
+
+	if svc == nil {
+		// Resource no longer exists
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.serviceModel = apiToModelService(*svc)
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state
+func (r *serviceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan serviceResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	if err := r.client.UpdateService(ctx, modelToAPIService(plan.serviceModel)); err != nil {
+		addClientError(&resp.Diagnostics, "Error updating Service", err)
+		return
+	}
+
+	updated, err := r.client.ReadService(ctx, plan.Name.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error reading updated Service", err)
+		return
+	}
+	if updated == nil {
+		resp.Diagnostics.AddError("Error after update", "Service was not found after update")
+		return
+	}
+
+	plan.serviceModel = apiToModelService(*updated)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state
+func (r *serviceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state serviceResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.DeleteService(ctx, state.Name.ValueString())
+	// An object that is already gone counts as deleted
+	if err != nil && !isNotFound(err) {
+		addClientError(&resp.Diagnostics, "Error deleting Service", err)
+		return
+	}
+}
+
+// ImportState handles resource import
+func (r *serviceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import by name
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+// modelToAPIService converts the Terraform model to the API structure.
+// Source ports and ICMP codes left out match everything.
+func modelToAPIService(model serviceModel) *service.Service {
+	svc := &service.Service{
+		Name:           model.Name.ValueString(),
+		Description:    model.Description.ValueString(),
+		Type:           model.Type.ValueString(),
+		ServiceDetails: &service.ServiceDetails{Details: make([]service.ServiceDetail, 0, len(model.Details))},
+	}
+
+	for _, detail := range model.Details {
+		var apiDetail service.ServiceDetail
+		switch svc.Type {
+		case service.TypeTCPOrUDP:
+			apiDetail.Protocol = detail.Protocol.ValueString()
+			apiDetail.SourcePort = valueOr(detail.SourcePort, allPorts)
+			apiDetail.DestinationPort = detail.DestinationPort.ValueString()
+		case service.TypeIP:
+			apiDetail.ProtocolName = detail.ProtocolName.ValueString()
+		case service.TypeICMP:
+			apiDetail.ICMPType = detail.ICMPType.ValueString()
+			apiDetail.ICMPCode = valueOr(detail.ICMPCode, anyICMPCode)
+		case service.TypeICMPv6:
+			apiDetail.ICMPv6Type = detail.ICMPType.ValueString()
+			apiDetail.ICMPv6Code = valueOr(detail.ICMPCode, anyICMPCode)
+		}
+		svc.ServiceDetails.Details = append(svc.ServiceDetails.Details, apiDetail)
+	}
+
+	return svc
+}
+
+// valueOr returns the value, or the fallback when it is null or unknown
+func valueOr(value types.String, fallback string) string {
+	if value.IsNull() || value.IsUnknown() {
+		return fallback
+	}
+	return value.ValueString()
+}
+
+// apiToModelService converts the API structure to the Terraform model. Only
+// the service_details attributes of the service type are set, the others
+// are null.
+func apiToModelService(svc service.Service) serviceModel {
+	model := serviceModel{
+		Name:        types.StringValue(svc.Name),
+		Description: types.StringValue(svc.Description),
+		Type:        types.StringValue(svc.Type),
+		Details:     []serviceDetailModel{},
+	}
+	if svc.ServiceDetails == nil {
+		return model
+	}
+
+	for _, apiDetail := range svc.ServiceDetails.Details {
+		detail := serviceDetailModel{
+			Protocol:        types.StringNull(),
+			SourcePort:      types.StringNull(),
+			DestinationPort: types.StringNull(),
+			ProtocolName:    types.StringNull(),
+			ICMPType:        types.StringNull(),
+			ICMPCode:        types.StringNull(),
+		}
+		switch svc.Type {
+		case service.TypeTCPOrUDP:
+			detail.Protocol = types.StringValue(apiDetail.Protocol)
+			detail.SourcePort = types.StringValue(apiDetail.SourcePort)
+			detail.DestinationPort = types.StringValue(apiDetail.DestinationPort)
+		case service.TypeIP:
+			detail.ProtocolName = types.StringValue(apiDetail.ProtocolName)
+		case service.TypeICMP:
+			detail.ICMPType = types.StringValue(apiDetail.ICMPType)
+			detail.ICMPCode = types.StringValue(apiDetail.ICMPCode)
+		case service.TypeICMPv6:
+			detail.ICMPType = types.StringValue(apiDetail.ICMPv6Type)
+			detail.ICMPCode = types.StringValue(apiDetail.ICMPv6Code)
+		}
+		model.Details = append(model.Details, detail)
+	}
+
+	return model
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/common"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/service"
+)
+
+// serviceGroupType describes the Service Group resource and data source
+var serviceGroupType = groupType[service.ServiceGroup]{
+	typeName: "_service_group",
+	label:    "Service Group",
+	members:  "services",
+	membersSchema: schema.SetAttribute{
+		Description: "Set of services in the Service Group",
+		Required:    true,
+		ElementType: types.StringType,
+	},
+	client: func(base *common.BaseClient) groupClient[service.ServiceGroup] {
+		client := service.NewClient(base)
+		return groupClient[service.ServiceGroup]{
+			create: client.CreateServiceGroup,
+			read:   client.ReadServiceGroup,
+			update: client.UpdateServiceGroup,
+			delete: client.DeleteServiceGroup,
+		}
+	},
+	toAPI:   modelToAPIServiceGroup,
+	fromAPI: apiToModelServiceGroup,
+}
+
+// NewServiceGroupResource creates a new resource
+func NewServiceGroupResource() resource.Resource {
+	return newGroupResource(serviceGroupType)
+}
+
+// modelToAPIServiceGroup converts the Terraform model to the API structure
+func modelToAPIServiceGroup(model groupModel) *service.ServiceGroup {
+	return &service.ServiceGroup{
+		Name:        model.Name.ValueString(),
+		Description: model.Description.ValueString(),
+		ServiceList: &service.ServiceList{Services: setStrings(model.Members)},
+	}
+}
+
+// apiToModelServiceGroup converts the API structure to the Terraform model
+func apiToModelServiceGroup(group service.ServiceGroup) groupModel {
+	model := groupModel{
+		Name:        types.StringValue(group.Name),
+		Description: types.StringValue(group.Description),
+		Members:     []types.String{},
+	}
+	if group.ServiceList != nil {
+		model.Members = stringSet(group.ServiceList.Services)
+	}
+	return model
+}
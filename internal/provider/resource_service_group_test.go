@@ -0,0 +1,29 @@
+package provider
+
+import "testing"
+
+// serviceGroupTest describes the Service Group acceptance tests
+var serviceGroupTest = groupTest{
+	resourceType: "sophosfirewall_service_group",
+	entityType:   "ServiceGroup",
+	members:      "services",
+	membersSet:   true,
+	entities: []string{
+		`<Services><Name>http</Name><Type>TCPorUDP</Type><ServiceDetails><ServiceDetail><SourcePort>1:65535</SourcePort><DestinationPort>80</DestinationPort><Protocol>TCP</Protocol></ServiceDetail></ServiceDetails></Services>`,
+		`<Services><Name>https</Name><Type>TCPorUDP</Type><ServiceDetails><ServiceDetail><SourcePort>1:65535</SourcePort><DestinationPort>443</DestinationPort><Protocol>TCP</Protocol></ServiceDetail></ServiceDetails></Services>`,
+	},
+	name:         "web",
+	description:  "Web services",
+	created:      []string{"https"},
+	updated:      []string{"https", "http"},
+	ruleArgument: "services",
+}
+
+func TestAccServiceGroupResource(t *testing.T) {
+	testAccGroupResource(t, serviceGroupTest)
+}
+
+func TestServiceGroupModelRoundTrip(t *testing.T) {
+	// A set cannot hold duplicates
+	testGroupRoundTrip(t, serviceGroupType, stringSet)
+}
@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"math/rand"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/service"
+)
+
+func TestAccServiceResource(t *testing.T) {
+	server, providerConfig := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckEntityDestroyed(server, "Services", "web"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "sophosfirewall_service" "test" {
+  name = "web"
+  type = "TCPorUDP"
+  service_details = [
+    {
+      protocol         = "TCP"
+      destination_port = "443"
+    },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckEntityExists(server, "Services", "web"),
+					resource.TestCheckResourceAttr("sophosfirewall_service.test", "service_details.#", "1"),
+					resource.TestCheckResourceAttr("sophosfirewall_service.test", "service_details.0.source_port", "1:65535"),
+					resource.TestCheckResourceAttr("sophosfirewall_service.test", "service_details.0.destination_port", "443"),
+					resource.TestCheckNoResourceAttr("sophosfirewall_service.test", "service_details.0.icmp_code"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "sophosfirewall_service" "test" {
+  name        = "web"
+  description = "Web and QUIC"
+  type        = "TCPorUDP"
+  service_details = [
+    {
+      protocol         = "TCP"
+      source_port      = "1024:65535"
+      destination_port = "8080:8090"
+    },
+    {
+      protocol         = "UDP"
+      destination_port = "443"
+    },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sophosfirewall_service.test", "description", "Web and QUIC"),
+					resource.TestCheckResourceAttr("sophosfirewall_service.test", "service_details.#", "2"),
+					resource.TestCheckResourceAttr("sophosfirewall_service.test", "service_details.0.source_port", "1024:65535"),
+					resource.TestCheckResourceAttr("sophosfirewall_service.test", "service_details.1.protocol", "UDP"),
+				),
+			},
+			{
+				ResourceName:                         "sophosfirewall_service.test",
+				ImportState:                          true,
+				ImportStateId:                        "web",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+				ImportStateVerifyIgnore:              []string{"timeouts"},
+			},
+		},
+	})
+}
+
+func TestAccServiceResourceICMP(t *testing.T) {
+	server, providerConfig := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckEntityDestroyed(server, "Services", "ping"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "sophosfirewall_service" "test" {
+  name = "ping"
+  type = "ICMPv6"
+  service_details = [
+    {
+      icmp_type = "Echo Request"
+    },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sophosfirewall_service.test", "service_details.0.icmp_type", "Echo Request"),
+					resource.TestCheckResourceAttr("sophosfirewall_service.test", "service_details.0.icmp_code", "Any Code"),
+					resource.TestCheckNoResourceAttr("sophosfirewall_service.test", "service_details.0.source_port"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccServiceResourceValidation(t *testing.T) {
+	_, providerConfig := testAccServer(t)
+
+	var steps []resource.TestStep
+	for _, tc := range []struct {
+		details string
+		err     string
+	}{
+		{`{ protocol = "TCP", destination_port = "0" }`, `Invalid Port`},
+		{`{ protocol = "TCP", destination_port = "90:80" }`, `Invalid Port`},
+		{`{ protocol = "SCTP", destination_port = "80" }`, `Invalid Protocol`},
+		{`{ protocol = "TCP" }`, `requires the destination_port attribute`},
+		{`{ protocol = "TCP", destination_port = "80", icmp_type = "Echo Request" }`, `cannot have the icmp_type attribute`},
+	} {
+		steps = append(steps, resource.TestStep{
+			Config: providerConfig + `
+resource "sophosfirewall_service" "test" {
+  name            = "web"
+  type            = "TCPorUDP"
+  service_details = [` + tc.details + `]
+}
+`,
+			ExpectError: regexp.MustCompile(tc.err),
+		})
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps:                    steps,
+	})
+}
+
+func TestValidPortRange(t *testing.T) {
+	for value, want := range map[string]bool{
+		"80":         true,
+		"1:65535":    true,
+		"8080:8080":  true,
+		"0":          false,
+		"65536":      false,
+		"90:80":      false,
+		"+80":        false,
+		"80:":        false,
+		"80-90":      false,
+		"1:2:3":      false,
+		"":           false,
+		" 80":        false,
+		"0080:00090": true,
+	} {
+		if got := validPortRange(value); got != want {
+			t.Errorf("validPortRange(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+// randomServiceModel returns a random model in the form it is read from the
+// appliance: only the service_details attributes of the type are set
+func randomServiceModel(r *rand.Rand) serviceModel {
+	serviceTypes := []string{service.TypeTCPOrUDP, service.TypeIP, service.TypeICMP, service.TypeICMPv6}
+	model := serviceModel{
+		Name:        types.StringValue(randomName(r)),
+		Description: types.StringValue(randomText(r)),
+		Type:        types.StringValue(serviceTypes[r.Intn(len(serviceTypes))]),
+		Details:     []serviceDetailModel{},
+	}
+	for n := r.Intn(3) + 1; n > 0; n-- {
+		detail := serviceDetailModel{
+			Protocol:        types.StringNull(),
+			SourcePort:      types.StringNull(),
+			DestinationPort: types.StringNull(),
+			ProtocolName:    types.StringNull(),
+			ICMPType:        types.StringNull(),
+			ICMPCode:        types.StringNull(),
+		}
+		switch model.Type.ValueString() {
+		case service.TypeTCPOrUDP:
+			detail.Protocol = types.StringValue(randomName(r))
+			detail.SourcePort = types.StringValue(randomName(r))
+			detail.DestinationPort = types.StringValue(randomName(r))
+		case service.TypeIP:
+			detail.ProtocolName = types.StringValue(randomName(r))
+		default:
+			detail.ICMPType = types.StringValue(randomName(r))
+			detail.ICMPCode = types.StringValue(randomName(r))
+		}
+		model.Details = append(model.Details, detail)
+	}
+	return model
+}
+
+func TestServiceModelRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < roundTripRuns; i++ {
+		model := randomServiceModel(r)
+		if got := apiToModelService(*modelToAPIService(model)); !reflect.DeepEqual(got, model) {
+			t.Fatalf("round trip of\n%+v\n= %+v", model, got)
+		}
+	}
+}
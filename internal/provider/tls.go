@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"crypto/tls"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/common"
+)
+
+// tlsVersions maps min_tls_version values to crypto/tls versions
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// applyTLSConfig copies the TLS attributes of the provider block into the
+// client configuration, reporting invalid values on their attribute
+func applyTLSConfig(diags *diag.Diagnostics, config SophosProviderModel, clientConfig *common.Config) {
+	switch {
+	case !config.CACertPEM.IsNull() && !config.CACertFile.IsNull():
+		diags.AddAttributeError(
+			path.Root("ca_cert_file"),
+			"Conflicting CA Certificate Sources",
+			"Only one of ca_cert_pem and ca_cert_file may be set.",
+		)
+	case !config.CACertPEM.IsNull():
+		clientConfig.CACertPEM = []byte(config.CACertPEM.ValueString())
+	case !config.CACertFile.IsNull():
+		data, err := os.ReadFile(config.CACertFile.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("ca_cert_file"), "Unable to Read CA Certificate File", err.Error())
+			break
+		}
+		clientConfig.CACertPEM = data
+	}
+
+	clientConfig.TLSServerName = config.TLSServerName.ValueString()
+
+	if !config.CertFingerprint.IsNull() {
+		fingerprint, err := common.ParseCertFingerprint(config.CertFingerprint.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("cert_fingerprint_sha256"), "Invalid Certificate Fingerprint", err.Error())
+		}
+		clientConfig.CertFingerprint = fingerprint
+	}
+
+	if !config.MinTLSVersion.IsNull() {
+		version, ok := tlsVersions[config.MinTLSVersion.ValueString()]
+		if !ok {
+			diags.AddAttributeError(
+				path.Root("min_tls_version"),
+				"Invalid Minimum TLS Version",
+				"The min_tls_version must be one of 1.0, 1.1, 1.2 or 1.3, got "+config.MinTLSVersion.ValueString()+".",
+			)
+		}
+		clientConfig.MinTLSVersion = version
+	}
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/common"
+)
+
+// Client for Service and ServiceGroup operations
+type Client struct {
+	*common.BaseClient
+}
+
+// NewClient creates a new Service client
+func NewClient(baseClient *common.BaseClient) *Client {
+	return &Client{
+		BaseClient: baseClient,
+	}
+}
+
+// CreateService implements single service creation
+func (c *Client) CreateService(ctx context.Context, service *Service) error {
+	service.TransactionID = ""
+
+	if _, err := c.Execute(ctx, common.OperationAdd, service); err != nil {
+		return fmt.Errorf("error creating service: %w", err)
+	}
+
+	return nil
+}
+
+// ReadService implements service reading
+func (c *Client) ReadService(ctx context.Context, name string) (*Service, error) {
+	response, err := c.Execute(ctx, common.OperationGet, common.Ref(EntityTypeService, name))
+	if err != nil {
+		var notFound *common.NotFoundError
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading service: %w", err)
+	}
+
+	services, err := common.DecodeEntities[Service](response, EntityTypeService)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the service with the matching name
+	for i := range services {
+		if services[i].Name == name {
+			normalizeService(&services[i])
+			return &services[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// ListServices reads every service with an unfiltered Get
+func (c *Client) ListServices(ctx context.Context) ([]Service, error) {
+	response, err := c.Execute(ctx, common.OperationGet, common.Ref(EntityTypeService, ""))
+	if err != nil {
+		var notFound *common.NotFoundError
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error listing services: %w", err)
+	}
+
+	services, err := common.DecodeEntities[Service](response, EntityTypeService)
+	if err != nil {
+		return nil, err
+	}
+	for i := range services {
+		normalizeService(&services[i])
+	}
+	return services, nil
+}
+
+// normalizeService makes sure the details of the service are never nil
+func normalizeService(service *Service) {
+	if service.ServiceDetails == nil {
+		service.ServiceDetails = &ServiceDetails{}
+	}
+}
+
+// UpdateService implements service updating
+func (c *Client) UpdateService(ctx context.Context, service *Service) error {
+	service.TransactionID = ""
+
+	if _, err := c.Execute(ctx, common.OperationUpdate, service); err != nil {
+		return fmt.Errorf("error updating service: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteService implements service deletion
+func (c *Client) DeleteService(ctx context.Context, name string) error {
+	if _, err := c.Execute(ctx, common.OperationRemove, common.Ref(EntityTypeService, name)); err != nil {
+		return fmt.Errorf("error deleting service: %w", err)
+	}
+
+	return nil
+}
+
+// CreateServiceGroup implements single service group creation
+func (c *Client) CreateServiceGroup(ctx context.Context, group *ServiceGroup) error {
+	group.TransactionID = ""
+
+	if _, err := c.Execute(ctx, common.OperationAdd, group); err != nil {
+		return fmt.Errorf("error creating service group: %w", err)
+	}
+
+	return nil
+}
+
+// ReadServiceGroup implements service group reading
+func (c *Client) ReadServiceGroup(ctx context.Context, name string) (*ServiceGroup, error) {
+	response, err := c.Execute(ctx, common.OperationGet, common.Ref(EntityTypeServiceGroup, name))
+	if err != nil {
+		var notFound *common.NotFoundError
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading service group: %w", err)
+	}
+
+	groups, err := common.DecodeEntities[ServiceGroup](response, EntityTypeServiceGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the service group with the matching name
+	for i := range groups {
+		if groups[i].Name == name {
+			normalizeServiceGroup(&groups[i])
+			return &groups[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// ListServiceGroups reads every service group with an unfiltered Get
+func (c *Client) ListServiceGroups(ctx context.Context) ([]ServiceGroup, error) {
+	response, err := c.Execute(ctx, common.OperationGet, common.Ref(EntityTypeServiceGroup, ""))
+	if err != nil {
+		var notFound *common.NotFoundError
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error listing service groups: %w", err)
+	}
+
+	groups, err := common.DecodeEntities[ServiceGroup](response, EntityTypeServiceGroup)
+	if err != nil {
+		return nil, err
+	}
+	for i := range groups {
+		normalizeServiceGroup(&groups[i])
+	}
+	return groups, nil
+}
+
+// normalizeServiceGroup sorts and deduplicates the services of the group,
+// as the appliance does not keep their order
+func normalizeServiceGroup(group *ServiceGroup) {
+	if group.ServiceList == nil {
+		group.ServiceList = &ServiceList{}
+	}
+	services := slices.Clone(group.ServiceList.Services)
+	slices.Sort(services)
+	group.ServiceList.Services = slices.Compact(services)
+	if group.ServiceList.Services == nil {
+		group.ServiceList.Services = []string{}
+	}
+}
+
+// UpdateServiceGroup implements service group updating
+func (c *Client) UpdateServiceGroup(ctx context.Context, group *ServiceGroup) error {
+	group.TransactionID = ""
+
+	if _, err := c.Execute(ctx, common.OperationUpdate, group); err != nil {
+		return fmt.Errorf("error updating service group: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteServiceGroup implements service group deletion
+func (c *Client) DeleteServiceGroup(ctx context.Context, name string) error {
+	if _, err := c.Execute(ctx, common.OperationRemove, common.Ref(EntityTypeServiceGroup, name)); err != nil {
+		return fmt.Errorf("error deleting service group: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/common"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/xmlapitest"
+)
+
+func FuzzReadService(f *testing.F) {
+	for _, seed := range []string{
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<Services transactionid=""><Name>web</Name><Type>TCPorUDP</Type><ServiceDetails>` +
+			`<ServiceDetail><SourcePort>1:65535</SourcePort><DestinationPort>8080:8090</DestinationPort><Protocol>TCP</Protocol></ServiceDetail>` +
+			`</ServiceDetails></Services></Response>`,
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<Services transactionid=""><Name>web</Name><Type>ICMP</Type><ServiceDetails>` +
+			`<ServiceDetail><ICMPType>Echo Request</ICMPType><ICMPCode>Any Code</ICMPCode></ServiceDetail>` +
+			`</ServiceDetails></Services></Response>`,
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<Services><Status>No. of records Zero.</Status></Services></Response>`,
+		`<Response><Login><status>Authentication Failure</status></Login></Response>`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		service, err := NewClient(xmlapitest.NewBodyClient(t, body)).ReadService(context.Background(), "web")
+		if err != nil || service == nil {
+			return
+		}
+		if service.Name != "web" {
+			t.Errorf("read service %q, want web", service.Name)
+		}
+		if service.ServiceDetails == nil {
+			t.Fatal("service details are nil")
+		}
+	})
+}
+
+func FuzzReadServiceGroup(f *testing.F) {
+	for _, seed := range []string{
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<ServiceGroup transactionid=""><Name>web</Name>` +
+			`<ServiceList><Service>HTTPS</Service><Service>HTTP</Service><Service>HTTPS</Service></ServiceList></ServiceGroup></Response>`,
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<ServiceGroup transactionid=""><Name>web</Name><ServiceList/></ServiceGroup></Response>`,
+		`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>` +
+			`<ServiceGroup><Status>No. of records Zero.</Status></ServiceGroup></Response>`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		group, err := NewClient(xmlapitest.NewBodyClient(t, body)).ReadServiceGroup(context.Background(), "web")
+		if err != nil || group == nil {
+			return
+		}
+		if group.Name != "web" {
+			t.Errorf("read service group %q, want web", group.Name)
+		}
+		if group.ServiceList == nil {
+			t.Fatal("service list is nil")
+		}
+		if !slices.IsSorted(group.ServiceList.Services) || len(slices.Compact(slices.Clone(group.ServiceList.Services))) != len(group.ServiceList.Services) {
+			t.Errorf("services %q are not sorted and unique", group.ServiceList.Services)
+		}
+	})
+}
+
+func FuzzEncodeService(f *testing.F) {
+	f.Add("web", "Web traffic", "TCPorUDP", "TCP", "8080:8090")
+	f.Add(`a&b`, `<Description>`, `]]><![CDATA[`, `</Protocol><Protocol>evil`, "\r\n")
+
+	f.Fuzz(func(t *testing.T, name, description, serviceType, protocol, port string) {
+		want := Service{
+			Name:        name,
+			Description: description,
+			Type:        serviceType,
+			ServiceDetails: &ServiceDetails{Details: []ServiceDetail{
+				{SourcePort: port, DestinationPort: port, Protocol: protocol},
+				{ProtocolName: protocol, ICMPType: port, ICMPCode: port, ICMPv6Type: port, ICMPv6Code: port},
+			}},
+		}
+		data, err := common.EncodeRequest(common.OperationAdd, common.LoginXML{}, &want)
+		if err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+		services := xmlapitest.DecodeSet[Service](t, data, EntityTypeService)
+		if len(services) != 1 {
+			t.Fatalf("request %s carries %d services", data, len(services))
+		}
+		if xmlapitest.XMLText(name, description, serviceType, protocol, port) && !reflect.DeepEqual(services[0], want) {
+			t.Errorf("round trip = %+v, want %+v", services[0], want)
+		}
+	})
+}
+
+func FuzzEncodeServiceGroup(f *testing.F) {
+	f.Add("web", "Web services", "HTTP", "HTTPS")
+	f.Add(`a&b`, `<Description>`, `</Service><Service>evil`, "\r\n")
+
+	f.Fuzz(func(t *testing.T, name, description, service1, service2 string) {
+		want := ServiceGroup{
+			Name:        name,
+			Description: description,
+			ServiceList: &ServiceList{Services: []string{service1, service2}},
+		}
+		data, err := common.EncodeRequest(common.OperationAdd, common.LoginXML{}, &want)
+		if err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+		groups := xmlapitest.DecodeSet[ServiceGroup](t, data, EntityTypeServiceGroup)
+		if len(groups) != 1 {
+			t.Fatalf("request %s carries %d service groups", data, len(groups))
+		}
+		if xmlapitest.XMLText(name, description, service1, service2) && !reflect.DeepEqual(groups[0], want) {
+			t.Errorf("round trip = %+v, want %+v", groups[0], want)
+		}
+	})
+}
+
+func TestServiceClientEscaping(t *testing.T) {
+	ctx := context.Background()
+	client := NewClient(xmlapitest.NewEchoClient(t))
+
+	for _, name := range xmlapitest.HostileNames {
+		t.Run(name, func(t *testing.T) {
+			want := &Service{
+				Name:        name,
+				Description: "desc " + name,
+				Type:        TypeTCPOrUDP,
+				ServiceDetails: &ServiceDetails{Details: []ServiceDetail{
+					{SourcePort: "1:65535", DestinationPort: "8080", Protocol: "TCP"},
+				}},
+			}
+			if err := client.CreateService(ctx, want); err != nil {
+				t.Fatalf("create: %v", err)
+			}
+			got, err := client.ReadService(ctx, name)
+			if err != nil || got == nil {
+				t.Fatalf("read: %v, %v", got, err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("round trip mismatch:\n got  %#v\n want %#v", got, want)
+			}
+
+			want.Description = "updated " + name
+			if err := client.UpdateService(ctx, want); err != nil {
+				t.Fatalf("update: %v", err)
+			}
+			if got, _ := client.ReadService(ctx, name); got == nil || got.Description != want.Description {
+				t.Errorf("updated description = %#v, want %q", got, want.Description)
+			}
+
+			if err := client.DeleteService(ctx, name); err != nil {
+				t.Fatalf("delete: %v", err)
+			}
+			if got, err := client.ReadService(ctx, name); got != nil || err != nil {
+				t.Errorf("read after delete = %#v, %v", got, err)
+			}
+		})
+	}
+}
+
+func TestServiceGroupClientEscaping(t *testing.T) {
+	ctx := context.Background()
+	client := NewClient(xmlapitest.NewEchoClient(t))
+
+	for _, name := range xmlapitest.HostileNames {
+		t.Run(name, func(t *testing.T) {
+			want := &ServiceGroup{
+				Name:        name,
+				Description: "desc " + name,
+				ServiceList: &ServiceList{Services: []string{name}},
+			}
+			if err := client.CreateServiceGroup(ctx, want); err != nil {
+				t.Fatalf("create: %v", err)
+			}
+			got, err := client.ReadServiceGroup(ctx, name)
+			if err != nil || got == nil {
+				t.Fatalf("read: %v, %v", got, err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("round trip mismatch:\n got  %#v\n want %#v", got, want)
+			}
+
+			want.Description = "updated " + name
+			if err := client.UpdateServiceGroup(ctx, want); err != nil {
+				t.Fatalf("update: %v", err)
+			}
+			if got, _ := client.ReadServiceGroup(ctx, name); got == nil || got.Description != want.Description {
+				t.Errorf("updated description = %#v, want %q", got, want.Description)
+			}
+
+			if err := client.DeleteServiceGroup(ctx, name); err != nil {
+				t.Fatalf("delete: %v", err)
+			}
+			if got, err := client.ReadServiceGroup(ctx, name); got != nil || err != nil {
+				t.Errorf("read after delete = %#v, %v", got, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package service
+
+// Service represents the firewall service model
+type Service struct {
+	Name           string          `xml:"Name"`
+	Description    string          `xml:"Description"`
+	Type           string          `xml:"Type"`
+	ServiceDetails *ServiceDetails `xml:"ServiceDetails"`
+	TransactionID  string          `xml:"transactionid,attr"`
+}
+
+// ServiceDetails represents the protocol and port entries of a service
+type ServiceDetails struct {
+	Details []ServiceDetail `xml:"ServiceDetail"`
+}
+
+// ServiceDetail is one entry of a service. Which fields are set depends on
+// the type of the service.
+type ServiceDetail struct {
+	SourcePort      string `xml:"SourcePort,omitempty"`
+	DestinationPort string `xml:"DestinationPort,omitempty"`
+	Protocol        string `xml:"Protocol,omitempty"`
+	ProtocolName    string `xml:"ProtocolName,omitempty"`
+	ICMPType        string `xml:"ICMPType,omitempty"`
+	ICMPCode        string `xml:"ICMPCode,omitempty"`
+	ICMPv6Type      string `xml:"ICMPv6Type,omitempty"`
+	ICMPv6Code      string `xml:"ICMPv6Code,omitempty"`
+}
+
+// Service types
+const (
+	TypeTCPOrUDP = "TCPorUDP"
+	TypeIP       = "IP"
+	TypeICMP     = "ICMP"
+	TypeICMPv6   = "ICMPv6"
+)
+
+// ServiceGroup represents the firewall service group model
+type ServiceGroup struct {
+	Name          string       `xml:"Name"`
+	Description   string       `xml:"Description"`
+	ServiceList   *ServiceList `xml:"ServiceList"`
+	TransactionID string       `xml:"transactionid,attr"`
+}
+
+// ServiceList represents the services of a service group
+type ServiceList struct {
+	Services []string `xml:"Service"`
+}
+
+// EntityTypeService is the XML API tag of service objects
+const EntityTypeService = "Services"
+
+// EntityTypeServiceGroup is the XML API tag of service group objects
+const EntityTypeServiceGroup = "ServiceGroup"
+
+// EntityType returns the XML API tag of the service
+func (s Service) EntityType() string { return EntityTypeService }
+
+// EntityName returns the name of the service
+func (s Service) EntityName() string { return s.Name }
+
+// EntityType returns the XML API tag of the service group
+func (g ServiceGroup) EntityType() string { return EntityTypeServiceGroup }
+
+// EntityName returns the name of the service group
+func (g ServiceGroup) EntityName() string { return g.Name }
@@ -0,0 +1,352 @@
+package testserver
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Entity types served by the server
+const (
+	typeIPHost        = "IPHost"
+	typeIPHostGroup   = "IPHostGroup"
+	typeFQDNHost      = "FQDNHost"
+	typeFQDNHostGroup = "FQDNHostGroup"
+	typeMACHost       = "MACHost"
+	typeMACHostGroup  = "MACHostGroup"
+	typeFirewallRule  = "FirewallRule"
+	typeService       = "Services"
+	typeServiceGroup  = "ServiceGroup"
+)
+
+// supportedTypes lists the entity types the server stores
+var supportedTypes = []string{
+	typeIPHost, typeIPHostGroup, typeFQDNHost, typeFQDNHostGroup, typeMACHost, typeMACHostGroup,
+	typeFirewallRule, typeService, typeServiceGroup,
+}
+
+// networkTypes lists the entity types a firewall rule may use as network
+var networkTypes = []string{
+	typeIPHost, typeIPHostGroup, typeFQDNHost, typeFQDNHostGroup, typeMACHost, typeMACHostGroup,
+}
+
+// serviceTypes lists the entity types a firewall rule may use as service
+var serviceTypes = []string{typeService, typeServiceGroup}
+
+// grouping describes a group type whose members are kept on the groups.
+// Members list their groups as well, that list is derived on Get.
+type grouping struct {
+	member, group           string // entity types
+	groupsList, groupsTag   string // groups listed on a member
+	membersList, membersTag string // members listed on a group
+}
+
+// groupings lists the group types with their member types
+var groupings = []grouping{
+	{typeIPHost, typeIPHostGroup, "HostGroupList", "HostGroup", "HostList", "Host"},
+	{typeFQDNHost, typeFQDNHostGroup, "FQDNHostGroupList", "FQDNHostGroup", "FQDNHostList", "FQDNHost"},
+	{typeMACHost, typeMACHostGroup, "MACHostGroupList", "MACHostGroup", "MACHostList", "MACHost"},
+}
+
+func invalid(format string, args ...interface{}) status {
+	return status{statusInvalid.code, statusInvalid.message + " " + fmt.Sprintf(format, args...)}
+}
+
+// set adds or updates an entity. Without operation attribute the entity is
+// added or updated depending on whether it exists.
+func (s *Server) set(operation string, entity *node) status {
+	entityType := entity.XMLName.Local
+	if !slices.Contains(supportedTypes, entityType) {
+		return statusUnsupported
+	}
+	name := entity.text("Name")
+	if name == "" {
+		return invalid("Name is required.")
+	}
+
+	_, exists := s.entities[entityType][name]
+	switch {
+	case operation == "add" && exists:
+		return statusExists
+	case operation == "update" && !exists:
+		return statusNotFound
+	case operation != "" && operation != "add" && operation != "update":
+		return statusUnsupported
+	}
+
+	if st, ok := s.checkReferences(entity); !ok {
+		return st
+	}
+	if entityType == typeFirewallRule {
+		if st, ok := s.checkPosition(entity); !ok {
+			return st
+		}
+	}
+	s.store(entity)
+	return statusApplied
+}
+
+// checkReferences verifies that every entity the new one refers to exists
+func (s *Server) checkReferences(entity *node) (status, bool) {
+	for _, g := range groupings {
+		switch entity.XMLName.Local {
+		case g.member:
+			for _, group := range entity.list(g.groupsList, g.groupsTag) {
+				if !s.exists(group, g.group) {
+					return invalid("Host group %q does not exist.", group), false
+				}
+			}
+		case g.group:
+			for _, host := range entity.list(g.membersList, g.membersTag) {
+				if !s.exists(host, g.member) {
+					return invalid("Host %q does not exist.", host), false
+				}
+			}
+		}
+	}
+
+	switch entity.XMLName.Local {
+	case typeServiceGroup:
+		for _, service := range entity.list("ServiceList", "Service") {
+			if !s.exists(service, typeService) {
+				return invalid("Service %q does not exist.", service), false
+			}
+		}
+	case typeFirewallRule:
+		for _, network := range ruleNetworks(entity) {
+			if !s.exists(network, networkTypes...) {
+				return invalid("Network %q does not exist.", network), false
+			}
+		}
+		for _, service := range ruleServices(entity) {
+			if !s.exists(service, serviceTypes...) {
+				return invalid("Service %q does not exist.", service), false
+			}
+		}
+	}
+	return status{}, true
+}
+
+// ruleNetworks returns the source and destination networks of the network
+// or user policy of a rule
+func ruleNetworks(rule *node) []string {
+	var networks []string
+	for _, tag := range []string{"NetworkPolicy", "UserPolicy"} {
+		if policy := rule.child(tag); policy != nil {
+			networks = append(networks, policy.list("SourceNetworks", "Network")...)
+			networks = append(networks, policy.list("DestinationNetworks", "Network")...)
+		}
+	}
+	return networks
+}
+
+// ruleServices returns the services of the network or user policy of a rule
+func ruleServices(rule *node) []string {
+	var services []string
+	for _, tag := range []string{"NetworkPolicy", "UserPolicy"} {
+		if policy := rule.child(tag); policy != nil {
+			services = append(services, policy.list("Services", "Service")...)
+		}
+	}
+	return services
+}
+
+// checkPosition verifies the rule named by After or Before exists
+func (s *Server) checkPosition(rule *node) (status, bool) {
+	switch position := rule.text("Position"); position {
+	case "After", "Before":
+		anchor := rule.child(position)
+		if anchor == nil || !s.exists(anchor.text("Name"), typeFirewallRule) {
+			return invalid("Rule to position %s does not exist.", strings.ToLower(position)), false
+		}
+	}
+	return status{}, true
+}
+
+// exists reports whether an entity of one of the types exists
+func (s *Server) exists(name string, types ...string) bool {
+	for _, entityType := range types {
+		if _, ok := s.entities[entityType][name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// store saves the entity and updates group memberships and rule order
+func (s *Server) store(entity *node) {
+	entityType := entity.XMLName.Local
+	name := entity.text("Name")
+	if s.entities[entityType] == nil {
+		s.entities[entityType] = make(map[string]*node)
+	}
+	stored, exists := s.entities[entityType][name]
+
+	for _, g := range groupings {
+		members := s.groupMembers(g.group)
+		switch entityType {
+		case g.member:
+			// Memberships are kept on the groups, the group list of the
+			// member is derived on Get. A member without the list keeps
+			// its groups.
+			if entity.child(g.groupsList) == nil {
+				break
+			}
+			groups := entity.list(g.groupsList, g.groupsTag)
+			for group, hosts := range members {
+				members[group] = slices.DeleteFunc(hosts, func(h string) bool { return h == name })
+			}
+			for _, group := range groups {
+				members[group] = append(members[group], name)
+			}
+			entity = entity.without(g.groupsList)
+		case g.group:
+			members[name] = entity.list(g.membersList, g.membersTag)
+			entity = entity.without(g.membersList)
+		}
+	}
+
+	if entityType == typeFirewallRule {
+		s.placeRule(entity, exists)
+		if exists && entity.text("Position") == "" {
+			// A rule updated without a position keeps its place, and so
+			// the position it reports
+			for _, tag := range []string{"Position", "After", "Before"} {
+				if c := stored.child(tag); c != nil {
+					entity = entity.without(tag)
+					entity.Children = append(entity.Children, c)
+				}
+			}
+		}
+	} else if !exists {
+		s.names[entityType] = append(s.names[entityType], name)
+	}
+	s.entities[entityType][name] = entity
+}
+
+// groupMembers returns the members of the groups of the type by group name
+func (s *Server) groupMembers(groupType string) map[string][]string {
+	if s.members[groupType] == nil {
+		s.members[groupType] = make(map[string][]string)
+	}
+	return s.members[groupType]
+}
+
+// placeRule moves the rule to the position it asks for. Updates without a
+// position keep the rule where it is.
+func (s *Server) placeRule(rule *node, exists bool) {
+	name := rule.text("Name")
+	position := rule.text("Position")
+	if exists && position == "" {
+		return
+	}
+
+	order := slices.DeleteFunc(s.names[typeFirewallRule], func(n string) bool { return n == name })
+	switch position {
+	case "Top":
+		order = slices.Insert(order, 0, name)
+	case "After", "Before":
+		anchor := -1
+		if c := rule.child(position); c != nil {
+			anchor = slices.Index(order, c.text("Name"))
+		}
+		if anchor < 0 {
+			order = append(order, name)
+			break
+		}
+		if position == "After" {
+			anchor++
+		}
+		order = slices.Insert(order, anchor, name)
+	default:
+		order = append(order, name)
+	}
+	s.names[typeFirewallRule] = order
+}
+
+// view returns the entity as Get returns it, with the derived memberships
+func (s *Server) view(entityType, name string) *node {
+	entity := s.entities[entityType][name]
+	for _, g := range groupings {
+		switch entityType {
+		case g.member:
+			var groups []string
+			for _, group := range s.names[g.group] {
+				if slices.Contains(s.members[g.group][group], name) {
+					groups = append(groups, group)
+				}
+			}
+			return entity.withList(g.groupsList, g.groupsTag, groups)
+		case g.group:
+			return entity.withList(g.membersList, g.membersTag, s.members[g.group][name])
+		}
+	}
+	return entity
+}
+
+// get writes the entities matching the filter, or the placeholder the
+// appliance returns when nothing matches
+func (s *Server) get(body *strings.Builder, filter *node) {
+	entityType := filter.XMLName.Local
+	name := filter.text("Name")
+
+	matched := false
+	for _, candidate := range s.names[entityType] {
+		if name != "" && candidate != name {
+			continue
+		}
+		body.WriteString(s.view(entityType, candidate).marshal())
+		matched = true
+	}
+	if !matched {
+		fmt.Fprintf(body, `<%s><Status>No. of records Zero.</Status></%[1]s>`, entityType)
+	}
+}
+
+// remove deletes an entity unless a firewall rule or group still uses it
+func (s *Server) remove(entity *node) status {
+	entityType := entity.XMLName.Local
+	if !slices.Contains(supportedTypes, entityType) {
+		return statusUnsupported
+	}
+	name := entity.text("Name")
+	if !s.exists(name, entityType) {
+		return statusNotFound
+	}
+
+	if slices.Contains(networkTypes, entityType) {
+		for _, ruleName := range s.names[typeFirewallRule] {
+			if slices.Contains(ruleNetworks(s.entities[typeFirewallRule][ruleName]), name) {
+				return statusReferenced
+			}
+		}
+	}
+	if slices.Contains(serviceTypes, entityType) {
+		for _, ruleName := range s.names[typeFirewallRule] {
+			if slices.Contains(ruleServices(s.entities[typeFirewallRule][ruleName]), name) {
+				return statusReferenced
+			}
+		}
+	}
+	if entityType == typeService {
+		for _, group := range s.entities[typeServiceGroup] {
+			if slices.Contains(group.list("ServiceList", "Service"), name) {
+				return statusReferenced
+			}
+		}
+	}
+
+	delete(s.entities[entityType], name)
+	s.names[entityType] = slices.DeleteFunc(s.names[entityType], func(n string) bool { return n == name })
+	for _, g := range groupings {
+		switch entityType {
+		case g.member:
+			for group, hosts := range s.members[g.group] {
+				s.members[g.group][group] = slices.DeleteFunc(hosts, func(h string) bool { return h == name })
+			}
+		case g.group:
+			delete(s.members[g.group], name)
+		}
+	}
+	return statusApplied
+}
@@ -0,0 +1,92 @@
+package testserver
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// node is an XML element kept verbatim, so entities are returned exactly as
+// they were stored
+type node struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Text     string     `xml:",chardata"`
+	Children []*node    `xml:",any"`
+}
+
+// child returns the first child element with the given name
+func (n *node) child(name string) *node {
+	for _, c := range n.Children {
+		if c.XMLName.Local == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// text returns the trimmed text of the named child, or "" when it is missing
+func (n *node) text(name string) string {
+	if c := n.child(name); c != nil {
+		return strings.TrimSpace(c.Text)
+	}
+	return ""
+}
+
+// list returns the texts of the item elements inside the named child, e.g.
+// the Host elements of a HostList
+func (n *node) list(name, item string) []string {
+	c := n.child(name)
+	if c == nil {
+		return nil
+	}
+	var items []string
+	for _, i := range c.Children {
+		if i.XMLName.Local == item {
+			items = append(items, strings.TrimSpace(i.Text))
+		}
+	}
+	return items
+}
+
+// without returns a shallow copy of the node without the named children
+func (n *node) without(names ...string) *node {
+	clone := &node{XMLName: n.XMLName, Text: n.Text}
+	for _, c := range n.Children {
+		drop := false
+		for _, name := range names {
+			drop = drop || c.XMLName.Local == name
+		}
+		if !drop {
+			clone.Children = append(clone.Children, c)
+		}
+	}
+	return clone
+}
+
+// withList returns a shallow copy of the node with a list child holding
+// items, e.g. a HostList of Host elements. No child is added for no items.
+func (n *node) withList(name, item string, items []string) *node {
+	clone := n.without(name)
+	if len(items) == 0 {
+		return clone
+	}
+	list := &node{XMLName: xml.Name{Local: name}}
+	for _, i := range items {
+		list.Children = append(list.Children, &node{XMLName: xml.Name{Local: item}, Text: i})
+	}
+	clone.Children = append(clone.Children, list)
+	return clone
+}
+
+// marshal encodes the node as returned by Get, with an empty transactionid
+// like the appliance
+func (n *node) marshal() string {
+	out := *n
+	out.Attrs = []xml.Attr{{Name: xml.Name{Local: "transactionid"}}}
+	data, err := xml.Marshal(&out)
+	if err != nil {
+		// Nodes are decoded from XML, so they always encode again
+		panic(err)
+	}
+	return string(data)
+}
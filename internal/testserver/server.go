@@ -0,0 +1,281 @@
+// Package testserver implements an in-memory Sophos Firewall XML API for
+// tests and offline development. It answers /webconsole/APIController for
+// IP, FQDN and MAC hosts and their groups, services, service groups and
+// firewall rules with the status codes of the appliance, and can inject
+// latency, empty bodies and 5xx responses. Serve it with httptest.NewServer(testserver.New()), or with
+// http.ListenAndServe to point a local Terraform configuration at it.
+package testserver
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults of a new Server
+const (
+	DefaultUsername   = "admin"
+	DefaultPassword   = "password"
+	DefaultAPIVersion = "2000.1"
+)
+
+// APIPath is the path of the XML API controller
+const APIPath = "/webconsole/APIController"
+
+// Fault changes how the server answers requests
+type Fault struct {
+	// Latency delays the answer
+	Latency time.Duration
+	// EmptyBody answers with status 200 and no body
+	EmptyBody bool
+	// StatusCode answers with this HTTP status and no body
+	StatusCode int
+	// Apply processes the request before the fault is answered, like an
+	// appliance whose response is lost on the way back
+	Apply bool
+	// Count is the number of requests affected, zero means one
+	Count int
+}
+
+// Server is an in-memory Sophos Firewall XML API
+type Server struct {
+	mu         sync.Mutex
+	username   string
+	password   string
+	apiVersion string
+	denyAPI    bool
+	faults     []Fault
+	requests   int
+
+	// entities holds every stored entity by type and name, names keeps
+	// their order, which for firewall rules is the rule order
+	entities map[string]map[string]*node
+	names    map[string][]string
+	// members holds the members of each group in order, by group type
+	// and group name
+	members map[string]map[string][]string
+}
+
+// New returns an empty server accepting DefaultUsername and DefaultPassword
+func New() *Server {
+	return &Server{
+		username:   DefaultUsername,
+		password:   DefaultPassword,
+		apiVersion: DefaultAPIVersion,
+		entities:   make(map[string]map[string]*node),
+		names:      make(map[string][]string),
+		members:    make(map[string]map[string][]string),
+	}
+}
+
+// SetCredentials changes the accepted username and password
+func (s *Server) SetCredentials(username, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.username, s.password = username, password
+}
+
+// SetAPIVersion changes the APIVersion reported in responses
+func (s *Server) SetAPIVersion(version string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.apiVersion = version
+}
+
+// SetAPIAccess rejects all requests like an appliance that does not allow
+// API access from the client address when allowed is false
+func (s *Server) SetAPIAccess(allowed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.denyAPI = !allowed
+}
+
+// AddFault queues a fault for the next requests. Faults are used in the
+// order they were added.
+func (s *Server) AddFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if fault.Count <= 0 {
+		fault.Count = 1
+	}
+	s.faults = append(s.faults, fault)
+}
+
+// RequestCount returns the number of API requests received
+func (s *Server) RequestCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// Put stores an entity given as XML, e.g. "<IPHost><Name>web</Name></IPHost>",
+// without checking references. It replaces an entity with the same name.
+func (s *Server) Put(entityXML string) error {
+	var entity node
+	if err := xml.Unmarshal([]byte(entityXML), &entity); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.store(&entity)
+	return nil
+}
+
+// Entity returns the entity as Get would return it
+func (s *Server) Entity(entityType, name string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.entities[entityType][name]; !ok {
+		return "", false
+	}
+	return s.view(entityType, name).marshal(), true
+}
+
+// Names returns the names of the stored entities of a type in order. For
+// firewall rules this is the rule order.
+func (s *Server) Names(entityType string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.names[entityType]...)
+}
+
+// request is the XML API request envelope
+type request struct {
+	Login struct {
+		Username string `xml:"Username"`
+		Password string `xml:"Password"`
+	} `xml:"Login"`
+	Set    *block `xml:"Set"`
+	Get    *block `xml:"Get"`
+	Remove *block `xml:"Remove"`
+}
+
+// block is a Set, Get or Remove element
+type block struct {
+	Operation string  `xml:"operation,attr"`
+	Entities  []*node `xml:",any"`
+}
+
+// ServeHTTP answers XML API requests
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != APIPath {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	fault := s.nextFault()
+	if fault.Latency > 0 {
+		select {
+		case <-time.After(fault.Latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	failed := fault.EmptyBody || fault.StatusCode != 0
+	if failed && !fault.Apply {
+		writeFault(w, fault)
+		return
+	}
+
+	var req request
+	if err := xml.Unmarshal([]byte(r.FormValue("reqxml")), &req); err != nil {
+		http.Error(w, "invalid reqxml: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	body := s.handle(&req)
+	if failed {
+		writeFault(w, fault)
+		return
+	}
+	w.Header().Set("Content-Type", "text/xml")
+	_, _ = w.Write([]byte(body))
+}
+
+// nextFault takes the fault for this request from the queue
+func (s *Server) nextFault() Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+	if len(s.faults) == 0 {
+		return Fault{}
+	}
+	fault := s.faults[0]
+	s.faults[0].Count--
+	if s.faults[0].Count <= 0 {
+		s.faults = s.faults[1:]
+	}
+	return fault
+}
+
+func writeFault(w http.ResponseWriter, fault Fault) {
+	if fault.StatusCode != 0 {
+		w.WriteHeader(fault.StatusCode)
+	}
+}
+
+// handle processes the request and returns the response body
+func (s *Server) handle(req *request) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var body strings.Builder
+	fmt.Fprintf(&body, `<Response APIVersion="%s" IPS_CAT_VER="1">`, s.apiVersion)
+
+	if s.denyAPI {
+		body.WriteString(`<Status code="534">API operations are not allowed from the requester IP address.</Status>`)
+		return body.String() + `</Response>`
+	}
+	if req.Login.Username != s.username || req.Login.Password != s.password {
+		body.WriteString(`<Login><status>Authentication Failure</status></Login>`)
+		return body.String() + `</Response>`
+	}
+	body.WriteString(`<Login><status>Authentication Successful</status></Login>`)
+
+	switch {
+	case req.Set != nil:
+		for _, entity := range req.Set.Entities {
+			writeStatus(&body, entity.XMLName.Local, s.set(req.Set.Operation, entity))
+		}
+	case req.Get != nil:
+		for _, filter := range req.Get.Entities {
+			s.get(&body, filter)
+		}
+	case req.Remove != nil:
+		for _, entity := range req.Remove.Entities {
+			writeStatus(&body, entity.XMLName.Local, s.remove(entity))
+		}
+	}
+	return body.String() + `</Response>`
+}
+
+// status is the outcome of a Set or Remove of one entity
+type status struct {
+	code    int
+	message string
+}
+
+var (
+	statusApplied     = status{200, "Configuration applied successfully."}
+	statusInvalid     = status{501, "Configuration parameters validation failed."}
+	statusExists      = status{502, "Operation failed. Entity having same name already exists."}
+	statusNotFound    = status{503, "Operation failed. Entity not found."}
+	statusReferenced  = status{510, "Operation failed. Entity is referenced by other entities."}
+	statusUnsupported = status{529, "Input request file is Invalid."}
+)
+
+func writeStatus(body *strings.Builder, entityType string, st status) {
+	fmt.Fprintf(body, `<%s transactionid=""><Status code="%d">%s</Status></%[1]s>`, entityType, st.code, escape(st.message))
+}
+
+func escape(text string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(text))
+	return b.String()
+}
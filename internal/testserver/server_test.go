@@ -0,0 +1,421 @@
+package testserver_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/common"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/firewallrule"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/fqdnhost"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/iphost"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/iphostgroup"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/machost"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/machostgroup"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/service"
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/testserver"
+)
+
+// newClient starts the server and returns a client logged in with the
+// default credentials
+func newClient(t *testing.T, server *testserver.Server, config common.Config) *common.BaseClient {
+	t.Helper()
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+
+	config.Endpoint = ts.URL
+	if config.Username == "" {
+		config.Username, config.Password = testserver.DefaultUsername, testserver.DefaultPassword
+	}
+	config.RetryWaitMin = time.Millisecond
+	config.RetryWaitMax = 5 * time.Millisecond
+	client, err := common.NewBaseClient(config)
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	return client
+}
+
+func TestHostGroupMembership(t *testing.T) {
+	ctx := context.Background()
+	base := newClient(t, testserver.New(), common.Config{})
+	hosts, groups := iphost.NewClient(base), iphostgroup.NewClient(base)
+
+	if err := groups.CreateIPHostGroup(ctx, &iphostgroup.IPHostGroup{Name: "servers", IPFamily: "IPv4"}); err != nil {
+		t.Fatalf("create group: %v", err)
+	}
+	host := &iphost.IPHost{
+		Name: "web", IPFamily: "IPv4", HostType: "IP", IPAddress: "10.0.0.1",
+		HostGroupList: &iphost.HostGroupList{HostGroups: []string{"servers"}},
+	}
+	if err := hosts.CreateIPHost(ctx, host); err != nil {
+		t.Fatalf("create host: %v", err)
+	}
+
+	group, err := groups.ReadIPHostGroup(ctx, "servers")
+	if err != nil || group == nil {
+		t.Fatalf("read group: %v, %v", group, err)
+	}
+	if group.HostList == nil || !slices.Equal(group.HostList.Hosts, []string{"web"}) {
+		t.Errorf("group hosts = %+v, want [web]", group.HostList)
+	}
+
+	// Emptying the group must show on the host as well
+	if err := groups.UpdateIPHostGroup(ctx, &iphostgroup.IPHostGroup{Name: "servers", IPFamily: "IPv4"}); err != nil {
+		t.Fatalf("update group: %v", err)
+	}
+	got, err := hosts.ReadIPHost(ctx, "web")
+	if err != nil || got == nil {
+		t.Fatalf("read host: %v, %v", got, err)
+	}
+	if got.HostGroupList != nil && len(got.HostGroupList.HostGroups) > 0 {
+		t.Errorf("host groups = %v, want none", got.HostGroupList.HostGroups)
+	}
+}
+
+func TestFQDNHostGroupMembership(t *testing.T) {
+	ctx := context.Background()
+	client := fqdnhost.NewClient(newClient(t, testserver.New(), common.Config{}))
+
+	if err := client.CreateFQDNHost(ctx, &fqdnhost.FQDNHost{Name: "office", FQDN: "*.office.com"}); err != nil {
+		t.Fatalf("create host: %v", err)
+	}
+	group := &fqdnhost.FQDNHostGroup{
+		Name:         "saas",
+		FQDNHostList: &fqdnhost.FQDNHostList{FQDNHosts: []string{"office"}},
+	}
+	if err := client.CreateFQDNHostGroup(ctx, group); err != nil {
+		t.Fatalf("create group: %v", err)
+	}
+
+	host, err := client.ReadFQDNHost(ctx, "office")
+	if err != nil || host == nil {
+		t.Fatalf("read host: %v, %v", host, err)
+	}
+	if !slices.Equal(host.FQDNHostGroupList.FQDNHostGroups, []string{"saas"}) {
+		t.Errorf("host groups = %v, want [saas]", host.FQDNHostGroupList.FQDNHostGroups)
+	}
+
+	// Groups only take existing FQDN hosts
+	group.FQDNHostList.FQDNHosts = []string{"office", "missing"}
+	if err := client.UpdateFQDNHostGroup(ctx, group); err == nil {
+		t.Error("group with a missing host was accepted")
+	}
+
+	// An update without the group list keeps the host in its groups
+	host.FQDNHostGroupList = nil
+	if err := client.UpdateFQDNHost(ctx, host); err != nil {
+		t.Fatalf("update host: %v", err)
+	}
+	got, err := client.ReadFQDNHostGroup(ctx, "saas")
+	if err != nil || got == nil {
+		t.Fatalf("read group: %v, %v", got, err)
+	}
+	if !slices.Equal(got.FQDNHostList.FQDNHosts, []string{"office"}) {
+		t.Errorf("group hosts = %v, want [office]", got.FQDNHostList.FQDNHosts)
+	}
+
+	// Leaving the group must show on the group as well
+	host.FQDNHostGroupList = &fqdnhost.FQDNHostGroupList{}
+	if err := client.UpdateFQDNHost(ctx, host); err != nil {
+		t.Fatalf("update host: %v", err)
+	}
+	got, err = client.ReadFQDNHostGroup(ctx, "saas")
+	if err != nil || got == nil {
+		t.Fatalf("read group: %v, %v", got, err)
+	}
+	if len(got.FQDNHostList.FQDNHosts) > 0 {
+		t.Errorf("group hosts = %v, want none", got.FQDNHostList.FQDNHosts)
+	}
+}
+
+func TestMACHostGroupMembership(t *testing.T) {
+	ctx := context.Background()
+	base := newClient(t, testserver.New(), common.Config{})
+	hosts, groups := machost.NewClient(base), machostgroup.NewClient(base)
+
+	if err := groups.CreateMACHostGroup(ctx, &machostgroup.MACHostGroup{Name: "printers"}); err != nil {
+		t.Fatalf("create group: %v", err)
+	}
+	host := &machost.MACHost{
+		Name: "printer", Type: "MACAddress", MACAddress: "00:16:3e:00:00:01",
+		MACHostGroupList: &machost.MACHostGroupList{MACHostGroups: []string{"printers"}},
+	}
+	if err := hosts.CreateMACHost(ctx, host); err != nil {
+		t.Fatalf("create host: %v", err)
+	}
+
+	group, err := groups.ReadMACHostGroup(ctx, "printers")
+	if err != nil || group == nil {
+		t.Fatalf("read group: %v, %v", group, err)
+	}
+	if !slices.Equal(group.MACHostList.MACHosts, []string{"printer"}) {
+		t.Errorf("group hosts = %v, want [printer]", group.MACHostList.MACHosts)
+	}
+
+	// Groups only take existing MAC hosts
+	group.MACHostList.MACHosts = []string{"printer", "missing"}
+	if err := groups.UpdateMACHostGroup(ctx, group); err == nil {
+		t.Error("group with a missing host was accepted")
+	}
+
+	// Emptying the group must show on the host as well
+	if err := groups.UpdateMACHostGroup(ctx, &machostgroup.MACHostGroup{Name: "printers"}); err != nil {
+		t.Fatalf("update group: %v", err)
+	}
+	got, err := hosts.ReadMACHost(ctx, "printer")
+	if err != nil || got == nil {
+		t.Fatalf("read host: %v, %v", got, err)
+	}
+	if len(got.MACHostGroupList.MACHostGroups) > 0 {
+		t.Errorf("host groups = %v, want none", got.MACHostGroupList.MACHostGroups)
+	}
+}
+
+func TestReferentialIntegrity(t *testing.T) {
+	ctx := context.Background()
+	server := testserver.New()
+	base := newClient(t, server, common.Config{})
+	hosts, rules := iphost.NewClient(base), firewallrule.NewClient(base)
+
+	err := hosts.CreateIPHost(ctx, &iphost.IPHost{
+		Name: "web", IPFamily: "IPv4", HostType: "IP", IPAddress: "10.0.0.1",
+		HostGroupList: &iphost.HostGroupList{HostGroups: []string{"missing"}},
+	})
+	var validation *common.ValidationError
+	if !errors.As(err, &validation) {
+		t.Fatalf("expected validation error for unknown group, got %v", err)
+	}
+
+	if err := hosts.CreateIPHost(ctx, &iphost.IPHost{Name: "web", IPFamily: "IPv4", HostType: "IP", IPAddress: "10.0.0.1"}); err != nil {
+		t.Fatalf("create host: %v", err)
+	}
+	err = hosts.CreateIPHost(ctx, &iphost.IPHost{Name: "web", IPFamily: "IPv4", HostType: "IP", IPAddress: "10.0.0.1"})
+	if !errors.As(err, &validation) || validation.Code != "502" {
+		t.Fatalf("expected 502 for duplicate add, got %v", err)
+	}
+
+	rule := &firewallrule.FirewallRule{
+		Name: "allow-web", Status: "Enable", Position: "Bottom", PolicyType: "Network",
+		NetworkPolicy: &firewallrule.NetworkPolicy{
+			Action:              "Accept",
+			DestinationNetworks: &firewallrule.NetworkList{Networks: []string{"web"}},
+		},
+	}
+	if err := rules.CreateFirewallRule(ctx, rule); err != nil {
+		t.Fatalf("create rule: %v", err)
+	}
+	userRule := &firewallrule.FirewallRule{
+		Name: "allow-staff", Status: "Enable", Position: "Bottom", PolicyType: "User",
+		UserPolicy: &firewallrule.UserPolicy{
+			NetworkPolicy: firewallrule.NetworkPolicy{
+				Action:         "Accept",
+				SourceNetworks: &firewallrule.NetworkList{Networks: []string{"missing"}},
+			},
+			Identity: &firewallrule.IdentityList{Members: []string{"staff"}},
+		},
+	}
+	if err := rules.CreateFirewallRule(ctx, userRule); !errors.As(err, &validation) {
+		t.Fatalf("expected validation error for unknown network of a user rule, got %v", err)
+	}
+	userRule.UserPolicy.SourceNetworks.Networks = []string{"web"}
+	if err := rules.CreateFirewallRule(ctx, userRule); err != nil {
+		t.Fatalf("create user rule: %v", err)
+	}
+
+	var dependency *common.DependencyError
+	for _, name := range []string{"allow-web", "allow-staff"} {
+		if err := hosts.DeleteIPHost(ctx, "web"); !errors.As(err, &dependency) {
+			t.Fatalf("expected dependency error deleting a host used by a rule, got %v", err)
+		}
+		if err := rules.DeleteFirewallRule(ctx, name); err != nil {
+			t.Fatalf("delete rule %s: %v", name, err)
+		}
+	}
+	if err := hosts.DeleteIPHost(ctx, "web"); err != nil {
+		t.Fatalf("delete host: %v", err)
+	}
+	if _, ok := server.Entity("IPHost", "web"); ok {
+		t.Error("host still stored after delete")
+	}
+}
+
+func TestServiceReferences(t *testing.T) {
+	ctx := context.Background()
+	base := newClient(t, testserver.New(), common.Config{})
+	services, rules := service.NewClient(base), firewallrule.NewClient(base)
+
+	group := &service.ServiceGroup{Name: "web", ServiceList: &service.ServiceList{Services: []string{"https"}}}
+	var validation *common.ValidationError
+	if err := services.CreateServiceGroup(ctx, group); !errors.As(err, &validation) {
+		t.Fatalf("expected validation error for unknown service, got %v", err)
+	}
+	https := &service.Service{
+		Name: "https", Type: service.TypeTCPOrUDP,
+		ServiceDetails: &service.ServiceDetails{Details: []service.ServiceDetail{
+			{Protocol: "TCP", SourcePort: "1:65535", DestinationPort: "443"},
+		}},
+	}
+	if err := services.CreateService(ctx, https); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	if err := services.CreateServiceGroup(ctx, group); err != nil {
+		t.Fatalf("create group: %v", err)
+	}
+
+	rule := &firewallrule.FirewallRule{
+		Name: "allow-web", Status: "Enable", Position: "Bottom", PolicyType: "Network",
+		NetworkPolicy: &firewallrule.NetworkPolicy{
+			Action:   "Accept",
+			Services: &firewallrule.ServiceList{Services: []string{"missing"}},
+		},
+	}
+	if err := rules.CreateFirewallRule(ctx, rule); !errors.As(err, &validation) {
+		t.Fatalf("expected validation error for unknown service of a rule, got %v", err)
+	}
+	rule.NetworkPolicy.Services.Services = []string{"web"}
+	if err := rules.CreateFirewallRule(ctx, rule); err != nil {
+		t.Fatalf("create rule: %v", err)
+	}
+
+	var dependency *common.DependencyError
+	if err := services.DeleteServiceGroup(ctx, "web"); !errors.As(err, &dependency) {
+		t.Fatalf("expected dependency error deleting a group used by a rule, got %v", err)
+	}
+	if err := rules.DeleteFirewallRule(ctx, "allow-web"); err != nil {
+		t.Fatalf("delete rule: %v", err)
+	}
+	if err := services.DeleteService(ctx, "https"); !errors.As(err, &dependency) {
+		t.Fatalf("expected dependency error deleting a service in a group, got %v", err)
+	}
+	if err := services.DeleteServiceGroup(ctx, "web"); err != nil {
+		t.Fatalf("delete group: %v", err)
+	}
+	if err := services.DeleteService(ctx, "https"); err != nil {
+		t.Fatalf("delete service: %v", err)
+	}
+}
+
+func TestRulePosition(t *testing.T) {
+	ctx := context.Background()
+	server := testserver.New()
+	rules := firewallrule.NewClient(newClient(t, server, common.Config{}))
+
+	for _, rule := range []*firewallrule.FirewallRule{
+		{Name: "a", Position: "Bottom"},
+		{Name: "b", Position: "Top"},
+		{Name: "c", Position: "After", After: &firewallrule.RulePosition{Name: "b"}},
+	} {
+		if err := rules.CreateFirewallRule(ctx, rule); err != nil {
+			t.Fatalf("create %s: %v", rule.Name, err)
+		}
+	}
+	if got := server.Names("FirewallRule"); !slices.Equal(got, []string{"b", "c", "a"}) {
+		t.Errorf("rule order = %v, want [b c a]", got)
+	}
+
+	// An update without a position keeps the rule and its position
+	if err := rules.UpdateFirewallRule(ctx, &firewallrule.FirewallRule{Name: "c", Description: "moved"}); err != nil {
+		t.Fatalf("update c: %v", err)
+	}
+	if got := server.Names("FirewallRule"); !slices.Equal(got, []string{"b", "c", "a"}) {
+		t.Errorf("rule order after update = %v, want [b c a]", got)
+	}
+	if got, err := rules.ReadFirewallRule(ctx, "c"); err != nil || got == nil || got.Position != "After" || got.After == nil || got.After.Name != "b" {
+		t.Errorf("read c after update = %+v, %v", got, err)
+	}
+
+	err := rules.CreateFirewallRule(ctx, &firewallrule.FirewallRule{Name: "d", Position: "Before", Before: &firewallrule.RulePosition{Name: "x"}})
+	var validation *common.ValidationError
+	if !errors.As(err, &validation) {
+		t.Errorf("expected validation error for unknown anchor, got %v", err)
+	}
+}
+
+func TestMACHostRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	macs := machost.NewClient(newClient(t, testserver.New(), common.Config{}))
+
+	host := &machost.MACHost{Name: "printers", Type: "MACLIST", ListOfMACAddresses: []string{"00:11:22:33:44:55", "00:11:22:33:44:66"}}
+	if err := macs.CreateMACHost(ctx, host); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	got, err := macs.ReadMACHost(ctx, "printers")
+	if err != nil || got == nil {
+		t.Fatalf("read: %v, %v", got, err)
+	}
+	if !slices.Equal(got.ListOfMACAddresses, host.ListOfMACAddresses) {
+		t.Errorf("MAC addresses = %v, want %v", got.ListOfMACAddresses, host.ListOfMACAddresses)
+	}
+
+	if got, err := macs.ReadMACHost(ctx, "missing"); err != nil || got != nil {
+		t.Errorf("read missing = %v, %v, want nil, nil", got, err)
+	}
+	var notFound *common.NotFoundError
+	if err := macs.DeleteMACHost(ctx, "missing"); !errors.As(err, &notFound) {
+		t.Errorf("expected not found deleting a missing host, got %v", err)
+	}
+}
+
+func TestLoginChecks(t *testing.T) {
+	ctx := context.Background()
+
+	server := testserver.New()
+	base := newClient(t, server, common.Config{Username: "admin", Password: "wrong"})
+	var authErr *common.AuthError
+	if _, err := base.Login(ctx); !errors.As(err, &authErr) {
+		t.Errorf("expected auth error, got %v", err)
+	}
+
+	server = testserver.New()
+	server.SetAPIVersion("1905.1")
+	server.SetAPIAccess(false)
+	base = newClient(t, server, common.Config{})
+	var permission *common.PermissionError
+	if _, err := base.Login(ctx); !errors.As(err, &permission) {
+		t.Errorf("expected permission error, got %v", err)
+	}
+
+	server.SetAPIAccess(true)
+	base = newClient(t, server, common.Config{})
+	info, err := base.Login(ctx)
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	if info.APIVersion != "1905.1" {
+		t.Errorf("APIVersion = %q, want 1905.1", info.APIVersion)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	ctx := context.Background()
+	server := testserver.New()
+	hosts := iphost.NewClient(newClient(t, server, common.Config{MaxRetries: 2, RequestTimeout: 50 * time.Millisecond}))
+
+	// The add is applied but its response is lost, the retry must find the
+	// host instead of failing with 502
+	server.AddFault(testserver.Fault{EmptyBody: true, Apply: true})
+	if err := hosts.CreateIPHost(ctx, &iphost.IPHost{Name: "web", IPFamily: "IPv4", HostType: "IP", IPAddress: "10.0.0.1"}); err != nil {
+		t.Fatalf("create after lost response: %v", err)
+	}
+
+	server.AddFault(testserver.Fault{StatusCode: http.StatusServiceUnavailable})
+	server.AddFault(testserver.Fault{Latency: 200 * time.Millisecond})
+	before := server.RequestCount()
+	got, err := hosts.ReadIPHost(ctx, "web")
+	if err != nil || got == nil {
+		t.Fatalf("read after faults: %v, %v", got, err)
+	}
+	if n := server.RequestCount() - before; n != 3 {
+		t.Errorf("requests = %d, want 3", n)
+	}
+
+	server.AddFault(testserver.Fault{StatusCode: http.StatusBadGateway, Count: 3})
+	if _, err := hosts.ReadIPHost(ctx, "web"); err == nil {
+		t.Error("expected error once retries are exhausted")
+	}
+}
@@ -0,0 +1,128 @@
+// Package xmlapitest provides helpers for tests of the XML encoding of the
+// clients: a server that echoes entities back, clients answered with a fixed
+// body and a decoder for encoded requests
+package xmlapitest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/common"
+)
+
+// HostileNames contain characters that break XML built by string formatting
+var HostileNames = []string{
+	`a&b`,
+	`<Name>injected</Name>`,
+	`"double" and 'single' quotes`,
+	`]]><![CDATA[`,
+	`</IPHost><IPHost><Name>evil`,
+	`&amp; already escaped &lt;`,
+	`ünïcödé ✓ name`,
+}
+
+const (
+	echoUsername = `admin<&>"'`
+	echoPassword = `p&ss</Password><Username>root`
+)
+
+// echoEntity is an entity element as sent by a client
+type echoEntity struct {
+	XMLName xml.Name
+	Name    string `xml:"Name"`
+	Inner   string `xml:",innerxml"`
+}
+
+// echoBlock is the content of a Set, Get or Remove element
+type echoBlock struct {
+	Operation string       `xml:"operation,attr"`
+	Entities  []echoEntity `xml:",any"`
+}
+
+// echoRequest is a request as decoded by the echo server
+type echoRequest struct {
+	Login struct {
+		Username string `xml:"Username"`
+		Password string `xml:"Password"`
+	} `xml:"Login"`
+	Set    *echoBlock `xml:"Set"`
+	Get    *echoBlock `xml:"Get"`
+	Remove *echoBlock `xml:"Remove"`
+}
+
+// echoServer stores entities exactly as they are received and returns them
+// unchanged, so a value only survives the round trip if the client escaped
+// it when encoding and unescaped it when decoding.
+type echoServer struct {
+	t        *testing.T
+	mu       sync.Mutex
+	entities map[string]string
+}
+
+// NewEchoClient starts an echo server for the test and returns a client for it
+func NewEchoClient(t *testing.T) *common.BaseClient {
+	t.Helper()
+	s := &echoServer{t: t, entities: map[string]string{}}
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+	client, err := common.NewBaseClient(common.Config{
+		Endpoint: server.URL,
+		Username: echoUsername,
+		Password: echoPassword,
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	return client
+}
+
+func (s *echoServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req echoRequest
+	if err := xml.Unmarshal([]byte(r.FormValue("reqxml")), &req); err != nil {
+		s.t.Errorf("malformed request XML: %v\n%s", err, r.FormValue("reqxml"))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Login.Username != echoUsername || req.Login.Password != echoPassword {
+		s.t.Errorf("credentials changed in transit: %q / %q", req.Login.Username, req.Login.Password)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var body strings.Builder
+	body.WriteString(`<Response APIVersion="2000.1"><Login><status>Authentication Successful</status></Login>`)
+	switch {
+	case req.Set != nil:
+		for _, e := range req.Set.Entities {
+			s.entities[e.XMLName.Local+"/"+e.Name] = e.Inner
+			fmt.Fprintf(&body, `<%s transactionid=""><Status code="200">Configuration applied successfully.</Status></%[1]s>`, e.XMLName.Local)
+		}
+	case req.Get != nil:
+		for _, e := range req.Get.Entities {
+			inner, ok := s.entities[e.XMLName.Local+"/"+e.Name]
+			if !ok {
+				fmt.Fprintf(&body, `<%s><Status>No. of records Zero.</Status></%[1]s>`, e.XMLName.Local)
+				continue
+			}
+			fmt.Fprintf(&body, `<%s transactionid="">%s</%[1]s>`, e.XMLName.Local, inner)
+		}
+	case req.Remove != nil:
+		for _, e := range req.Remove.Entities {
+			key := e.XMLName.Local + "/" + e.Name
+			if _, ok := s.entities[key]; !ok {
+				fmt.Fprintf(&body, `<%s transactionid=""><Status code="503">Entity not found.</Status></%[1]s>`, e.XMLName.Local)
+				continue
+			}
+			delete(s.entities, key)
+			fmt.Fprintf(&body, `<%s transactionid=""><Status code="200">Configuration applied successfully.</Status></%[1]s>`, e.XMLName.Local)
+		}
+	}
+	body.WriteString(`</Response>`)
+	_, _ = w.Write([]byte(body.String()))
+}
@@ -0,0 +1,70 @@
+package xmlapitest
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/jubinaghara/terraform-provider-sophosfirewall/internal/common"
+)
+
+// roundTripFunc answers requests without a server
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+// NewBodyClient returns a client whose requests are all answered with body
+func NewBodyClient(t *testing.T, body []byte) *common.BaseClient {
+	t.Helper()
+	client, err := common.NewBaseClient(common.Config{Endpoint: "https://firewall.example"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	client.Client.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(bytes.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+	return client
+}
+
+// DecodeSet returns the entities of the type in the Set block of an encoded
+// request
+func DecodeSet[T any](t *testing.T, data []byte, entityType string) []T {
+	t.Helper()
+	var request struct {
+		Set struct {
+			Entities []common.EntityResult `xml:",any"`
+		}
+	}
+	if err := xml.Unmarshal(data, &request); err != nil {
+		t.Fatalf("decode %s: %v", data, err)
+	}
+	entities, err := common.DecodeEntities[T](&common.Response{Entities: request.Set.Entities}, entityType)
+	if err != nil {
+		t.Fatalf("decode entities: %v", err)
+	}
+	return entities
+}
+
+// XMLText reports whether the values survive XML encoding unchanged. The
+// encoder replaces invalid UTF-8 and characters XML cannot carry.
+func XMLText(values ...string) bool {
+	for _, value := range values {
+		if !utf8.ValidString(value) {
+			return false
+		}
+		for _, r := range value {
+			if r < 0x20 && r != '\t' && r != '\n' && r != '\r' || r == 0xFFFE || r == 0xFFFF {
+				return false
+			}
+		}
+	}
+	return true
+}